@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/decompose"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"os"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+func main() {
+	vector := flag.String("f", "0110100110010110", "truth vector of the function, '-' marks don't care")
+	backend := flag.String("backend", "qmc", "minimization algorithm for the two-level cover and the functions of the circuit")
+	costName := flag.String("cost", "literals", "cost to minimize: literals, terms, gates, transistors or weights=w0,w1,...")
+	flag.Parse()
+
+	spec, err := logic.ParseSpec(*vector)
+	if err != nil {
+		fail(err)
+	}
+	cost, err := logic.ParseCostModel(*costName)
+	if err != nil {
+		fail(err)
+	}
+	ctx, stop := cli.Interruptible()
+	defer stop()
+	options := logic.Options{Cost: cost}
+
+	twoLevel, err := logic.Minimize(ctx, *backend, spec, options)
+	if err != nil {
+		fail(err)
+	}
+	root, err := decompose.Bidecompose(ctx, spec, *backend, options)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("two-level: %s\n", twoLevel.Cover.PrettyString())
+	fmt.Printf("multi-level: %s\n", root.Formula())
+	fmt.Print(root.PrettyString())
+
+	gates, inputs := decompose.TwoLevelGates(twoLevel.Cover)
+	// Двухуровневая схема - вентили конъюнкций и выходной вентиль
+	depth := gates
+	if depth > 2 {
+		depth = 2
+	}
+	multiGates, multiInputs := root.Gates()
+	fmt.Printf("%-12s %6s %12s %6s\n", "circuit", "gates", "gate inputs", "depth")
+	fmt.Printf("%-12s %6d %12d %6d\n", "two-level", gates, inputs, depth)
+	fmt.Printf("%-12s %6d %12d %6d\n", "multi-level", multiGates, multiInputs, root.Depth())
+
+	mismatches, err := root.Verify(spec)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("verification: %d mismatches on %d points\n", len(mismatches), len(spec.Values))
+	for _, mismatch := range mismatches {
+		fmt.Println("  ", mismatch)
+	}
+	if len(mismatches) != 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/esop"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+// Наибольшее число переменных для перебора всех ФАЛ: 2^(2^4) функций
+const MaxExhaustiveVariables = 4
+
+// Наибольшее число переменных для случайных ФАЛ: точные алгоритмы
+// на больших векторах работают неприемлемо долго
+const MaxRandomVariables = 16
+
+// Функция проверяет, что число переменных n подходит для режима проверки
+func CheckVariables(n int, exhaustive bool) error {
+	if exhaustive && (n < 1 || n > MaxExhaustiveVariables) {
+		return fmt.Errorf("exhaustive check needs from 1 to %d variables, got %d", MaxExhaustiveVariables, n)
+	}
+	if n < 1 || n > MaxRandomVariables {
+		return fmt.Errorf("number of variables must be from 1 to %d, got %d", MaxRandomVariables, n)
+	}
+	return nil
+}
+
+// Функция печатает вектор значений ФАЛ строкой
+func VectorString(f []int) string {
+	var formatted string
+	for _, value := range f {
+		formatted += fmt.Sprint(value)
+	}
+	return formatted
+}
+
+// Функция возвращает описание расхождения результатов на векторе f
+// Пустая строка означает, что расхождений нет
+func Compare(f []int, results map[string]logic.Result, order []string) string {
+	var problems []string
+	for _, name := range order {
+		mismatches, err := results[name].Cover.Verify(f)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+		for _, mismatch := range mismatches {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, mismatch))
+		}
+	}
+	// Нижняя граница любого алгоритма не может превышать стоимость,
+	// достигнутую другим алгоритмом в той же форме
+	for _, name := range order {
+		for _, other := range order {
+			if results[name].Cover.Form != results[other].Cover.Form {
+				continue
+			}
+			if results[name].LowerBound > results[other].Cost {
+				problems = append(problems, fmt.Sprintf("lower bound %s=%d exceeds cost %s=%d",
+					name, results[name].LowerBound, other, results[other].Cost))
+			}
+		}
+	}
+	for _, name := range order[1:] {
+		if results[name].Cover.Form != results[order[0]].Cover.Form {
+			continue
+		}
+		if results[name].Cost != results[order[0]].Cost {
+			problems = append(problems, fmt.Sprintf("cost %s=%d, %s=%d",
+				order[0], results[order[0]].Cost, name, results[name].Cost))
+		}
+	}
+	return strings.Join(problems, "; ")
+}
+
+// Состояние проверки
+type Checker struct {
+	Minimizers []logic.Minimizer
+	Options    logic.Options
+}
+
+// Функция запускает все минимизаторы на векторе f и сравнивает результаты
+func (c Checker) Check(f []int) (map[string]logic.Result, string, error) {
+	results := make(map[string]logic.Result, len(c.Minimizers))
+	order := make([]string, 0, len(c.Minimizers))
+	for _, m := range c.Minimizers {
+		result, err := m.Minimize(context.Background(), logic.NewSpec(f), c.Options)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s failed: %v", m.Name(), err)
+		}
+		results[m.Name()] = result
+		order = append(order, m.Name())
+	}
+	return results, Compare(f, results, order), nil
+}
+
+// Функция уменьшает вектор с расхождением: поочередно заменяет единицы
+// нулями и оставляет замену, если расхождение сохраняется
+// Возвращает вектор, в котором ни одну единицу нельзя убрать
+func (c Checker) Shrink(f []int) ([]int, error) {
+	minimal := make([]int, len(f))
+	copy(minimal, f)
+	for changed := true; changed; {
+		changed = false
+		for i := range minimal {
+			if minimal[i] != 1 {
+				continue
+			}
+			minimal[i] = 0
+			_, problem, err := c.Check(minimal)
+			if err != nil {
+				return nil, err
+			}
+			if problem != "" {
+				changed = true
+			} else {
+				minimal[i] = 1
+			}
+		}
+	}
+	return minimal, nil
+}
+
+// Функция сообщает о расхождении на векторе f и его минимальном представителе
+func (c Checker) Report(f []int) error {
+	minimal, err := c.Shrink(f)
+	if err != nil {
+		return err
+	}
+	results, problem, err := c.Check(minimal)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("MISMATCH on %s\n", VectorString(f))
+	fmt.Printf("  minimal reproducer: %s\n", VectorString(minimal))
+	fmt.Printf("  %s\n", problem)
+	for _, m := range c.Minimizers {
+		fmt.Printf("  %s: %s (cost %d)\n", m.Name(), results[m.Name()].Cover.PrettyString(), results[m.Name()].Cost)
+	}
+	return nil
+}
+
+func main() {
+	n := flag.Int("n", 3, "number of variables")
+	exhaustive := flag.Bool("exhaustive", false, "check every function of n variables instead of random ones")
+	count := flag.Int("count", 100, "number of random functions to check")
+	seed := flag.Int64("seed", 1, "random seed")
+	backends := flag.String("backends", "qmc,nk", "comma separated minimization algorithms to compare")
+	costName := flag.String("cost", "literals", "cost to compare: literals, terms, gates, transistors or weights=w0,w1,...")
+	workers := flag.Int("workers", 1, "number of goroutines for the cover search of every backend")
+	flag.Parse()
+
+	if err := CheckVariables(*n, *exhaustive); err != nil {
+		fail(err)
+	}
+	var checker Checker
+	cost, err := logic.ParseCostModel(*costName)
+	if err != nil {
+		fail(err)
+	}
+	checker.Options.Cost = cost
+	checker.Options.Workers = *workers
+	for _, name := range strings.Split(*backends, ",") {
+		minimizer, err := logic.Lookup(name)
+		if err != nil {
+			fail(err)
+		}
+		checker.Minimizers = append(checker.Minimizers, minimizer)
+	}
+	if len(checker.Minimizers) < 2 {
+		fail(errors.New("at least two backends are needed"))
+	}
+
+	size := 1 << uint(*n)
+	total := *count
+	if *exhaustive {
+		total = 1 << uint(size)
+	}
+	random := rand.New(rand.NewSource(*seed))
+	mismatches := 0
+	for i := 0; i < total; i++ {
+		f := make([]int, size)
+		for point := range f {
+			if *exhaustive {
+				f[point] = i >> uint(size-1-point) & 1
+			} else {
+				f[point] = random.Intn(2)
+			}
+		}
+		_, problem, err := checker.Check(f)
+		if err != nil {
+			fail(err)
+		}
+		if problem == "" {
+			continue
+		}
+		mismatches++
+		if err := checker.Report(f); err != nil {
+			fail(err)
+		}
+	}
+	fmt.Printf("checked %d functions of %d variables, mismatches: %d\n", total, *n, mismatches)
+	if mismatches != 0 {
+		os.Exit(1)
+	}
+}
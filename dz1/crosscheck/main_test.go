@@ -0,0 +1,118 @@
+package main
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"strings"
+	"testing"
+)
+
+func checker(t *testing.T, names ...string) Checker {
+	t.Helper()
+	var c Checker
+	for _, name := range names {
+		m, err := logic.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Minimizers = append(c.Minimizers, m)
+	}
+	return c
+}
+
+// Все ФАЛ до 3 переменных, как crosscheck -exhaustive, проходят
+// без расхождений, включая константы
+func TestExhaustive(t *testing.T) {
+	tests := []struct {
+		backends []string
+		cost     logic.CostModel
+	}{
+		{[]string{"qmc", "nk"}, logic.LiteralCost{}},
+		{[]string{"qmc", "nk"}, logic.TermCost{}},
+		{[]string{"qmc", "qmc-sat", "nk"}, logic.GateInputCost{}},
+		{[]string{"qmc", "nk-cnf", "esop"}, logic.LiteralCost{}},
+	}
+	for _, test := range tests {
+		c := checker(t, test.backends...)
+		c.Options.Cost = test.cost
+		for n := 1; n <= 3; n++ {
+			size := 1 << uint(n)
+			for i := 0; i < 1<<uint(size); i++ {
+				f := make([]int, size)
+				for point := range f {
+					f[point] = i >> uint(size-1-point) & 1
+				}
+				_, problem, err := c.Check(f)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if problem != "" {
+					t.Errorf("%v (%s) on %s: %s", test.backends, test.cost.Name(), VectorString(f), problem)
+				}
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	f := []int{0, 1, 1, 1}
+	x0 := logic.Cube{Mask: 1, Values: 1}
+	x1 := logic.Cube{Mask: 2, Values: 2}
+	dnf := func(cubes ...logic.Cube) logic.Cover {
+		return logic.Cover{Variables: 2, Form: logic.DNF, Cubes: cubes}
+	}
+	tests := []struct {
+		name    string
+		results map[string]logic.Result
+		problem string
+	}{
+		{"equal", map[string]logic.Result{
+			"a": {Cover: dnf(x0, x1), Cost: 2, LowerBound: 2},
+			"b": {Cover: dnf(x1, x0), Cost: 2, LowerBound: 1},
+		}, ""},
+		{"wrong cover", map[string]logic.Result{
+			"a": {Cover: dnf(x0, x1), Cost: 2},
+			"b": {Cover: dnf(x0), Cost: 1},
+		}, "b: "},
+		{"cost", map[string]logic.Result{
+			"a": {Cover: dnf(x0, x1), Cost: 2},
+			"b": {Cover: dnf(x0, x1, logic.Cube{Mask: 3, Values: 3}), Cost: 4},
+		}, "cost a=2, b=4"},
+		{"bound", map[string]logic.Result{
+			"a": {Cover: dnf(x0, x1), Cost: 2, LowerBound: 3},
+			"b": {Cover: dnf(x0, x1), Cost: 2},
+		}, "lower bound a=3 exceeds cost a=2"},
+		{"other form", map[string]logic.Result{
+			"a": {Cover: dnf(x0, x1), Cost: 2},
+			"b": {Cover: logic.Cover{Variables: 2, Form: logic.CNF, Cubes: []logic.Cube{{Mask: 3}}}, Cost: 3, LowerBound: 3},
+		}, ""},
+	}
+	for _, test := range tests {
+		problem := Compare(f, test.results, []string{"a", "b"})
+		if test.problem == "" && problem != "" || !strings.Contains(problem, test.problem) {
+			t.Errorf("%s: problem %q, want %q", test.name, problem, test.problem)
+		}
+	}
+}
+
+func TestCheckVariables(t *testing.T) {
+	tests := []struct {
+		n          int
+		exhaustive bool
+		err        string
+	}{
+		{1, false, ""},
+		{16, false, ""},
+		{4, true, ""},
+		{0, false, "must be from 1 to 16, got 0"},
+		{17, false, "must be from 1 to 16, got 17"},
+		{32, false, "must be from 1 to 16, got 32"},
+		{5, true, "exhaustive check needs from 1 to 4 variables, got 5"},
+		{0, true, "exhaustive check needs from 1 to 4 variables, got 0"},
+	}
+	for _, test := range tests {
+		err := CheckVariables(test.n, test.exhaustive)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("CheckVariables(%d, %t) = %v, want %q", test.n, test.exhaustive, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/decompose"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+// Функция разбирает связанные переменные "0,2" в разбиение
+func parseBound(s string, n int) (decompose.Partition, error) {
+	var bound uint32
+	for _, field := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(field), "x"))
+		if err != nil || v < 0 || v >= n {
+			return decompose.Partition{}, fmt.Errorf("invalid bound variable %q of %d variables", field, n)
+		}
+		bound |= 1 << uint(v)
+	}
+	return decompose.NewPartition(n, bound), nil
+}
+
+func main() {
+	vector := flag.String("f", "0110100110010110", "truth vector of the function, '-' marks don't care")
+	lut := flag.Int("lut", 3, "largest number of inputs of a function in the tree (0 to decompose as far as possible)")
+	bound := flag.String("bound", "", "print the decomposition chart for these comma separated bound variables, e.g. 0,1")
+	all := flag.Bool("all", false, "print the column multiplicity of every partition")
+	backend := flag.String("backend", "qmc", "minimization algorithm for the functions of the tree")
+	costName := flag.String("cost", "literals", "cost to minimize: literals, terms, gates, transistors or weights=w0,w1,...")
+	flag.Parse()
+
+	spec, err := logic.ParseSpec(*vector)
+	if err != nil {
+		fail(err)
+	}
+	cost, err := logic.ParseCostModel(*costName)
+	if err != nil {
+		fail(err)
+	}
+
+	if *bound != "" {
+		p, err := parseBound(*bound, spec.Variables)
+		if err != nil {
+			fail(err)
+		}
+		chart, err := decompose.NewChart(spec, p)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("partition %s\n", p)
+		fmt.Print(chart.PrettyString())
+		return
+	}
+
+	charts, err := decompose.Charts(spec)
+	if err != nil {
+		fail(err)
+	}
+	simple := 0
+	for _, chart := range charts {
+		isSimple := len(chart.Bound) >= 2 && chart.Simple()
+		if isSimple {
+			simple++
+		}
+		if *all || isSimple {
+			note := ""
+			if isSimple {
+				note = " simple"
+			}
+			fmt.Printf("%s multiplicity %d%s\n", chart.Partition, chart.Multiplicity(), note)
+		}
+	}
+	fmt.Printf("simple disjoint decompositions: %d of %d partitions\n", simple, len(charts))
+
+	root, err := decompose.Decompose(spec, *lut)
+	if err != nil {
+		fail(err)
+	}
+	if err := root.Minimize(context.Background(), *backend, logic.Options{Cost: cost}); err != nil {
+		fail(err)
+	}
+	fmt.Print(root.PrettyString())
+	nodes := root.Nodes()
+	total := 0
+	for _, node := range nodes {
+		total += node.Cover.Cost(cost)
+	}
+	fmt.Printf("functions: %d, largest has %d inputs, cost (%s): %d\n", len(nodes), root.MaxInputs(), cost.Name(), total)
+	mismatches, err := root.Verify(spec)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("verification: %d mismatches on %d points\n", len(mismatches), len(spec.Values))
+	for _, mismatch := range mismatches {
+		fmt.Println("  ", mismatch)
+	}
+	if len(mismatches) != 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/esop"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"os"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+// Функция минимизирует ФАЛ алгоритмом backend, печатает покрытие
+// и проверяет его на всех наборах
+func minimize(ctx context.Context, backend string, spec logic.Spec, options logic.Options) logic.Result {
+	result, err := logic.Minimize(ctx, backend, spec, options)
+	if err != nil && result.Stats.Stopped == nil {
+		fail(err)
+	}
+	if result.Stats.Stopped != nil {
+		fmt.Fprintf(os.Stderr, "%s search stopped after %d nodes: %v\n", backend, result.Stats.Evaluated, result.Stats.Stopped)
+	}
+	fmt.Printf("%s: %s\n", result.Cover.Form, result.Cover.PrettyString())
+	mismatches, err := result.Cover.Verify(spec.Values)
+	if err != nil {
+		fail(err)
+	}
+	for _, mismatch := range mismatches {
+		fmt.Println("  ", mismatch)
+	}
+	if len(mismatches) != 0 {
+		fmt.Fprintf(os.Stderr, "%s cover has %d mismatches\n", backend, len(mismatches))
+		os.Exit(1)
+	}
+	return result
+}
+
+func main() {
+	vector := flag.String("f", "0110100110010110", "truth vector of the function, '-' marks don't care")
+	var search cli.Search
+	search.Register(flag.CommandLine, "search nodes", false)
+	flag.Parse()
+
+	spec, err := logic.ParseSpec(*vector)
+	if err != nil {
+		fail(err)
+	}
+	options, err := search.Options()
+	if err != nil {
+		fail(err)
+	}
+	cost := options.Cost
+	ctx, stop := cli.Interruptible()
+	defer stop()
+
+	dnf := minimize(ctx, "qmc", spec, options)
+	esop := minimize(ctx, "esop", spec, options)
+	fmt.Printf("%-5s %6s %9s %6s  %s\n", "form", "cubes", "literals", "cost", "certificate")
+	for _, result := range []logic.Result{dnf, esop} {
+		fmt.Printf("%-5s %6d %9d %6d  %s\n", result.Cover.Form, len(result.Cover.Cubes),
+			result.Cover.Literals(), result.Cost, result.Certificate())
+	}
+	fmt.Printf("cost (%s): esop - dnf = %d\n", cost.Name(), esop.Cost-dnf.Cost)
+}
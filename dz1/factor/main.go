@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/factor"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"os"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+func main() {
+	vector := flag.String("f", "0001011101111111", "truth vector of the function, '-' marks don't care")
+	backend := flag.String("backend", "qmc", "minimization algorithm for the two-level cover (must produce a DNF)")
+	costName := flag.String("cost", "literals", "cost to minimize: literals, terms, gates, transistors or weights=w0,w1,...")
+	kernels := flag.Bool("kernels", false, "print the kernels and co-kernels of the cover")
+	flag.Parse()
+
+	spec, err := logic.ParseSpec(*vector)
+	if err != nil {
+		fail(err)
+	}
+	cost, err := logic.ParseCostModel(*costName)
+	if err != nil {
+		fail(err)
+	}
+	ctx, stop := cli.Interruptible()
+	defer stop()
+	result, err := logic.Minimize(ctx, *backend, spec, logic.Options{Cost: cost})
+	if err != nil {
+		fail(err)
+	}
+	if result.Cover.Form != logic.DNF {
+		fail(fmt.Errorf("%s produces a %s cover, factoring needs a dnf", *backend, result.Cover.Form))
+	}
+	n := spec.Variables
+	f := factor.FromCover(result.Cover)
+	fmt.Printf("dnf: %s\n", f.Format(n))
+	if *kernels {
+		for _, k := range factor.Kernels(f) {
+			fmt.Printf("  co-kernel %s: kernel %s\n", k.CoKernel.Format(n), k.Kernel.Format(n))
+		}
+	}
+	factored := factor.Factor(f)
+	fmt.Printf("factored: %s\n", factored.Format(n))
+
+	network := factor.NewNetwork(n, f)
+	network.Extract()
+	fmt.Printf("network:\n%s\n", network)
+	fmt.Printf("factored network:\n%s\n", network.FactoredString())
+
+	fmt.Printf("%-18s %9s\n", "form", "literals")
+	fmt.Printf("%-18s %9d\n", "dnf", f.Literals())
+	fmt.Printf("%-18s %9d\n", "factored", factored.Literals())
+	fmt.Printf("%-18s %9d\n", "network", network.Literals())
+	fmt.Printf("%-18s %9d\n", "factored network", network.FactoredLiterals())
+
+	mismatches, err := network.Verify(spec)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("verification: %d mismatches on %d points\n", len(mismatches), len(spec.Values))
+	for _, mismatch := range mismatches {
+		fmt.Println("  ", mismatch)
+	}
+	if len(mismatches) != 0 {
+		os.Exit(1)
+	}
+}
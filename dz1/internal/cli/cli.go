@@ -0,0 +1,71 @@
+// Пакет cli содержит общие для команд dz1 флаги перебора и настройку
+// ввода-вывода
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// Флаги перебора, общие для команд минимизации
+type Search struct {
+	Cost     string
+	Timeout  time.Duration
+	MaxNodes int64
+	Progress bool
+	Workers  int
+}
+
+// Функция регистрирует в set флаги -cost, -timeout и -max-nodes; nodes
+// называет то, что считает -max-nodes. Флаги -progress и -workers нужны
+// только командам с параллельным поиском покрытия (parallel)
+func (s *Search) Register(set *flag.FlagSet, nodes string, parallel bool) {
+	set.StringVar(&s.Cost, "cost", "literals", "cost to minimize: literals, terms, gates, transistors or weights=w0,w1,...")
+	set.DurationVar(&s.Timeout, "timeout", 0, "stop the search after this time (0 for no limit)")
+	set.Int64Var(&s.MaxNodes, "max-nodes", 0, fmt.Sprintf("stop the search after this many %s (0 for no limit)", nodes))
+	if parallel {
+		set.BoolVar(&s.Progress, "progress", false, "report search progress to stderr")
+		set.IntVar(&s.Workers, "workers", 1, "number of goroutines for the cover search; the result does not depend on it")
+	}
+}
+
+// Функция разбирает модель стоимости и собирает параметры перебора;
+// ход перебора печатается в stderr
+func (s *Search) Options() (logic.Options, error) {
+	cost, err := logic.ParseCostModel(s.Cost)
+	if err != nil {
+		return logic.Options{}, err
+	}
+	options := logic.Options{
+		Cost:    cost,
+		Budget:  logic.Budget{Time: s.Timeout, Nodes: s.MaxNodes},
+		Workers: s.Workers,
+	}
+	if s.Progress {
+		options.Progress = func(p logic.Progress) {
+			fmt.Fprintln(os.Stderr, p)
+		}
+	}
+	return options, nil
+}
+
+// Функция возвращает контекст, который отменяется прерыванием: перебор
+// останавливается, и команда печатает лучший найденный результат
+func Interruptible() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// Функция выбирает поток для отчета: в режиме JSON stdout занят
+// результатом, и весь остальной вывод уходит в stderr
+func Report(json bool) io.Writer {
+	if json {
+		return os.Stderr
+	}
+	return os.Stdout
+}
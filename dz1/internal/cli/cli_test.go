@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"flag"
+	"github.com/AndreevSemen/asvt/logic"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		args     []string
+		parallel bool
+		options  logic.Options
+		progress bool
+		err      string
+	}{
+		{nil, true, logic.Options{Cost: logic.LiteralCost{}, Workers: 1}, false, ""},
+		{[]string{"-cost", "terms", "-timeout", "2s", "-max-nodes", "100", "-workers", "4", "-progress"}, true,
+			logic.Options{Cost: logic.TermCost{}, Budget: logic.Budget{Time: 2 * time.Second, Nodes: 100}, Workers: 4}, true, ""},
+		{[]string{"-max-nodes", "7"}, false, logic.Options{Cost: logic.LiteralCost{}, Budget: logic.Budget{Nodes: 7}}, false, ""},
+		{[]string{"-workers", "4"}, false, logic.Options{}, false, "flag provided but not defined: -workers"},
+		{[]string{"-cost", "volume"}, true, logic.Options{}, false, "volume"},
+	}
+	for _, test := range tests {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.SetOutput(ioutil.Discard)
+		var search Search
+		search.Register(set, "search nodes", test.parallel)
+		err := set.Parse(test.args)
+		var options logic.Options
+		if err == nil {
+			options, err = search.Options()
+		}
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%v: error %v, want %q", test.args, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", test.args, err)
+			continue
+		}
+		if options.Cost.Name() != test.options.Cost.Name() || options.Budget != test.options.Budget ||
+			options.Workers != test.options.Workers || (options.Progress != nil) != test.progress {
+			t.Errorf("%v: %+v, want %+v", test.args, options, test.options)
+		}
+	}
+}
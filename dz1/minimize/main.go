@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/esop"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"os"
+	"strings"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+func main() {
+	backend := flag.String("backend", "qmc", "minimization algorithm, see -list")
+	vector := flag.String("f", "01101000", "truth vector of the function, '-' marks don't care")
+	jsonOutput := flag.Bool("json", false, "print only the result as JSON")
+	var search cli.Search
+	search.Register(flag.CommandLine, "search nodes", true)
+	list := flag.Bool("list", false, "print available algorithms and exit")
+	flag.Parse()
+
+	if *list {
+		fmt.Println(strings.Join(logic.Names(), "\n"))
+		return
+	}
+
+	spec, err := logic.ParseSpec(*vector)
+	if err != nil {
+		fail(err)
+	}
+	options, err := search.Options()
+	if err != nil {
+		fail(err)
+	}
+	cost := options.Cost
+	ctx, stop := cli.Interruptible()
+	defer stop()
+	result, err := logic.Minimize(ctx, *backend, spec, options)
+	if err != nil && result.Stats.Stopped == nil {
+		fail(err)
+	}
+	if result.Stats.Stopped != nil {
+		fmt.Fprintf(os.Stderr, "search stopped after %d nodes: %v\n", result.Stats.Evaluated, result.Stats.Stopped)
+	}
+	f := spec.Values
+	if *jsonOutput {
+		encoded, err := json.Marshal(result.Cover.JSON(cost))
+		if err != nil {
+			fail(err)
+		}
+		fmt.Println(string(encoded))
+		return
+	}
+	fmt.Printf("backend: %s\n", *backend)
+	fmt.Printf("%s: %s\n", result.Cover.Form, result.Cover.PrettyString())
+	fmt.Printf("cost (%s): %d\n", cost.Name(), result.Cost)
+	fmt.Printf("certificate: %s\n", result.Certificate())
+	fmt.Printf("literals: %d, cubes: %d\n", result.Cover.Literals(), len(result.Cover.Cubes))
+	fmt.Printf("candidates: %d\n", result.Stats.Candidates)
+	fmt.Printf("time: %v\n", result.Stats.Duration)
+	mismatches, err := result.Cover.Verify(f)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("verification: %d mismatches on %d points\n", len(mismatches), len(f))
+	for _, mismatch := range mismatches {
+		fmt.Println("  ", mismatch)
+	}
+	if len(mismatches) != 0 {
+		os.Exit(1)
+	}
+}
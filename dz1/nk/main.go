@@ -1,419 +1,187 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
-	"math"
-	"strconv"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/nk"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 )
 
-type Var struct {
-	s string
-	Number int
-	Value bool
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
 }
 
-// Функция преобразования переменной в удобочитаемый вид
-func (v Var) PrettyString() string {
-	formatted := fmt.Sprintf("x%d", v.Number)
-	if !v.Value {
-		return "!" + formatted
-	}
-	return formatted
-}
-
-func (v Var) String() string {
-	if v.s == "" {
-		v.s = fmt.Sprintf("(%d,%t)", v.Number, v.Value)
-	}
-	return v.s
-}
-
-// Структура представляет собой неопределенный коэффициент
-type K []Var
-
-func (a K) PrettyString() string {
-	var prettyString string
-	for _, bit := range a {
-		prettyString = bit.PrettyString() + prettyString
-	}
-	return prettyString
-}
+func main() {
+	modeName := flag.String("mode", "exact", "search mode: exact (branch and bound) or greedy (fast)")
+	formName := flag.String("form", "dnf", "normal form to minimize: dnf or cnf")
+	vector := flag.String("f", "", "truth vector, e.g. 0110-1-0 (- marks undefined points)")
+	reduce := flag.Bool("reduce", true, "reduce the system by absorption and dominance before solving")
+	exportDir := flag.String("export", ".", "directory to write the method stages and the reduction.txt log to (empty to disable)")
+	exportFormatName := flag.String("export-format", "text", "stage export format: text, latex or json")
+	enumerationName := flag.String("all", "", "also list every minimal or every irredundant solution: minimal or irredundant")
+	var search cli.Search
+	search.Register(flag.CommandLine, "search nodes", true)
+	jsonOutput := flag.Bool("json", false, "print only the result as JSON to stdout")
+	flag.Parse()
+	out := cli.Report(*jsonOutput)
+	mode, err := nk.ParseMode(*modeName)
+	if err != nil {
+		fail(err)
+	}
+	form, err := nk.ParseForm(*formName)
+	if err != nil {
+		fail(err)
+	}
+	exportFormat, err := nk.ParseExportFormat(*exportFormatName)
+	if err != nil {
+		fail(err)
+	}
+	enumeration, err := nk.ParseEnumeration(*enumerationName)
+	if err != nil {
+		fail(err)
+	}
+	options, err := search.Options()
+	if err != nil {
+		fail(err)
+	}
+	costModel := options.Cost
+	cost := nk.Cost{Model: costModel, Form: form}
+	ctx, stop := cli.Interruptible()
+	defer stop()
 
-func (a K) KString() string {
-	var foramtted string
-	foramtted += "K_("
-	for _, v := range a {
-		foramtted += strconv.Itoa(v.Number)
+	f := []int{
+		0, 0, 0, 1, 0, 0, 1, 0, 0, 1, // 00-09
+		0, 1, 0, 1, 1, 1, 1, 0, 1, 1, // 10-19
+		1, 0, 0, 0, 0, 0, 0, 1, 0, 0, // 20-29
+		1, 1, 0, 1, 1, 1, 0, 0, 1, 0, // 30-39
+		0, 1, 0, 1, 1, 1, 0, 1, 1, 0, // 40-49
+		1, 1, 1, 0, 1, 1, 0, 1, 0, 0, // 50-59
+		0, 1, 0, 1, // 60-63*/
 	}
-	foramtted += ")^("
-	for _, v := range a {
-		if v.Value {
-			foramtted += "1"
-		} else {
-			foramtted += "0"
+	spec := logic.NewSpec(f)
+	if *vector != "" {
+		spec, err = logic.ParseSpec(*vector)
+		if err != nil {
+			fail(err)
 		}
+		f = spec.Values
 	}
-	foramtted += ")"
-	return foramtted
-}
-
-func (a K) String() string {
-	var stringed string
-	for _, v := range a {
-		stringed += v.String()
-	}
-	return stringed
-}
-
-func (a K) Equal(b K) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i].Number != b[i].Number || a[i].Value != b[i].Value {
-			return false
-		}
+	system, err := nk.MakeSystem(f, form)
+	if err != nil {
+		fail(err)
 	}
-	return true
-}
-
-func (a K) Less(b K) bool {
-	return len(a) < len(b)
-}
-
-func (a K) AppendVar(v Var) K {
-	newK := make(K, len(a))
-	copy(newK, a)
-	newK = append(newK, v)
-	return newK
-}
-
-func (a K) IsCovers(b K) bool {
-	for i := range a {
-		var found = false
-		for j := range b {
-			if a[i].Number == b[j].Number {
-				if a[i].Value != b[j].Value {
-					return false
-				}
-				found = true
-				break
+	fmt.Fprintln(out, "system with ")
+	for _, eq := range system {
+		fmt.Fprintln(out, eq.KString())
+	}
+	toSolve := system
+	if *reduce {
+		// Журнал упрощения пишется вместе с этапами метода
+		var log io.Writer = ioutil.Discard
+		if *exportDir != "" {
+			file, err := os.Create(filepath.Join(*exportDir, "reduction.txt"))
+			if err != nil {
+				fail(err)
 			}
+			defer file.Close()
+			log = file
 		}
-		if !found {
-			return false
-		}
-	}
-	return true
-}
-
-type Term []bool
-
-func (t Term) MakeAllSubsets() []K {
-	allSubsets := make([]K, 0, int(math.Pow(2, float64(len(t)))))
-	for number, value := range t {
-		v := Var{
-			Number: number,
-			Value:  value,
-		}
-		for _, subset := range allSubsets {
-			allSubsets = append(allSubsets, subset.AppendVar(v))
-		}
-		allSubsets = append(allSubsets, K{v})
-	}
-	return allSubsets
-}
-
-type Equation struct {
-	Coefficients []K
-	Value bool
-}
-
-func (e Equation) KString() string {
-	var formatted string
-	for i, k := range e.Coefficients {
-		if i != 0 {
-			formatted += " + "
-		}
-		formatted += k.KString()
+		toSolve = nk.Reduce(system, cost, log)
+		fmt.Fprintln(out, "system size after reduction:", len(toSolve))
 	}
-	formatted += " = "
-	if e.Value {
-		formatted += "1"
-	} else {
-		formatted += "0"
-	}
-	return formatted
-}
-
-func (e Equation) Include(q Equation) bool {
-	for _, qk := range q.Coefficients {
-		var found = false
-		for _, ek := range e.Coefficients {
-			if qk.Equal(ek) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+	tracker := logic.NewTracker(ctx, options)
+	// Константу записывает пустой коэффициент, которого в системе нет
+	constant := form.IsConstant(f)
+	result := []nk.K{{}}
+	if !constant {
+		result = nk.Solve(toSolve, mode, cost, tracker)
 	}
-	return true
-}
-
-func (e *Equation) ExcludeCoefficient(k K) {
-	newCoefficients := make([]K, 0, len(e.Coefficients))
-	for _, old := range e.Coefficients {
-		if !old.Equal(k) {
-			newCoefficients = append(newCoefficients, old)
-		}
+	if err := tracker.Err(); err != nil {
+		fmt.Fprintf(out, "search stopped after %d nodes: %v\n", tracker.Evaluated(), err)
 	}
-	e.Coefficients = newCoefficients
-}
-
-// Функция возвращает СДНФ от ФАЛ
-func MakeSystemOfEquations(f []int) []Equation {
-	variableNumber := int(math.Log2(float64(len(f))))
-	format := "%0" + strconv.Itoa(variableNumber) + "b"
-
-	equations := make([]Equation, 0, len(f))
-	for i := range f {
-		term := make(Term, 0, variableNumber)
-		binaryString := fmt.Sprintf(format, i)
-		for _, char := range binaryString {
-			switch char {
-			case '0':
-				term = append(term, false)
-			case '1':
-				term = append(term, true)
-			default:
-				panic(fmt.Sprintf("unexpected char: %s", string(char)))
-			}
-		}
 
-		var value bool
-		switch f[i] {
-		case 0:
-			value = false
-		case 1:
-			value = true
-		default:
-			panic(fmt.Sprintf("unexpected boolean value: %d", f[i]))
+	if *exportDir != "" {
+		stages, err := nk.MakeStages(f, form, system, result)
+		if err != nil {
+			fail(err)
 		}
-
-		equations = append(equations, Equation{
-			Coefficients: term.MakeAllSubsets(),
-			Value:        value,
-		})
-	}
-	return equations
-}
-
-func ExcludeZeroCoefficients(system []Equation) []Equation {
-	newSystem := make([]Equation, 0, len(system))
-	for _, equationToExclude := range system {
-		if equationToExclude.Value == true {
-			for _, zeroEquation := range system {
-				if zeroEquation.Value == false {
-					for _, zeroCoefficient := range zeroEquation.Coefficients {
-						equationToExclude.ExcludeCoefficient(zeroCoefficient)
-					}
-				}
-			}
-			newSystem = append(newSystem, equationToExclude)
+		err = nk.Export(*exportDir, exportFormat, stages)
+		if err != nil {
+			fail(err)
 		}
 	}
-	return newSystem
-}
-
-type KS []K
 
-func (ks KS) Complexity() int {
-	complexity := 0
-	for _, r := range ks {
-		complexity += len(r)
+	fmt.Fprintln(out, "result system:")
+	for _, eq := range nk.ExcludeOther(system, result) {
+		fmt.Fprintln(out, eq.KString())
 	}
-	return complexity
-}
 
-func GetMostRepeated(system []Equation) ([]K, [][]Equation) {
-	type repetition struct {
-		Count int
-		K
-	}
-
-	set := make(map[string]repetition)
-	for _, equation := range system {
-		for _, coefficient := range equation.Coefficients {
-			if r, found := set[coefficient.String()]; found {
-				r.Count++
-				set[coefficient.String()] = r
-			} else {
-				set[coefficient.String()] = repetition{
-					Count: 1,
-					K:     coefficient,
-				}
-			}
-		}
+	// Проверяем результат на всех определенных наборах
+	mismatches, err := form.Verify(f, result)
+	if err != nil {
+		fail(err)
 	}
-	maxRepeat := 0
-	for _, rep := range set {
-		if rep.Count > maxRepeat {
-			maxRepeat = rep.Count
-		}
+	for _, mismatch := range mismatches {
+		fmt.Fprintln(out, mismatch)
 	}
+	fmt.Fprintf(out, "verification: %d mismatches on %d points\n", len(mismatches), len(f))
 
-	minSize := math.MaxInt32
-	for _, rep := range set {
-		if rep.Count == maxRepeat {
-			if len(rep.K) < minSize {
-				minSize = len(rep.K)
-			}
-		}
+	undefined, err := nk.CoveredUndefined(f, result)
+	if err != nil {
+		fail(err)
 	}
-
-	var withMaxRepeats []K
-	for _, rep := range set {
-		if rep.Count == maxRepeat && len(rep.K) == minSize {
-			withMaxRepeats = append(withMaxRepeats, rep.K)
+	if len(undefined) != 0 {
+		fmt.Fprintf(out, "undefined points covered by result (defined as %s):\n", form.CoveredValue())
+		for _, eq := range undefined {
+			fmt.Fprintf(out, "f(%s): %s\n", eq.Term, eq.KString())
 		}
 	}
 
-	var newSystems [][]Equation
-	for _, repeat := range withMaxRepeats {
-		var newSystem []Equation
-		for _, equation := range system {
-			var isSolved = false
-			for _, coefficient := range equation.Coefficients {
-				if coefficient.Equal(repeat) {
-					isSolved = true
-					break
-				}
-			}
-			if !isSolved {
-				newSystem = append(newSystem, equation)
-			}
+	var variants [][]nk.K
+	switch enumeration {
+	case nk.AllMinimal:
+		variants = nk.GetAllMinimalVariants(system, cost, logic.NewTracker(ctx, options))
+		if constant {
+			variants = [][]nk.K{result}
 		}
-		newSystems = append(newSystems, newSystem)
-	}
-	return withMaxRepeats, newSystems
-}
-
-func GetMinimalVariant(system []Equation, result []K) []K {
-	if len(system) == 0 {
-		return result
+	case nk.AllIrredundant:
+		variants = nk.GetAllIrredundantVariants(system, cost, logic.NewTracker(ctx, options))
 	}
-	for {
-		mostRepeateds, newSystems := GetMostRepeated(system)
-		if len(mostRepeateds) == 1 {
-			mostRepeated, newSystem := mostRepeateds[0], newSystems[0]
-
-			result = append(result, mostRepeated)
-			system = newSystem
-
-			combSet := make(map[string]struct{})
-			for _, k := range result {
-				combSet[k.String()] = struct{}{}
-			}
-
-			var isSystemSolved = true
-			for _, eq := range system {
-				var isEquationSolved = false
-				for _, eqK := range eq.Coefficients {
-					if _, found := combSet[eqK.String()]; found {
-						isEquationSolved = true
-						break
-					}
-				}
-				if !isEquationSolved {
-					isSystemSolved = false
-					break
-				}
-			}
-			if isSystemSolved {
-				return result
-			}
-		} else {
-			var possibleResults [][]K
-			for i := range mostRepeateds {
-				possibleResult := GetMinimalVariant(newSystems[i], append(result, mostRepeateds[i]))
-				possibleResults = append(possibleResults, possibleResult)
-			}
-
-			minComplexity := math.MaxInt32
-			var withMinComplexity []K
-			for i := range possibleResults {
-				complexity := KS(possibleResults[i]).Complexity()
-				if complexity < minComplexity {
-					minComplexity = complexity
-					withMinComplexity = possibleResults[i]
-				}
-			}
-			return withMinComplexity
+	for i, variant := range variants {
+		fmt.Fprintf(out, "solution %d (cost %d): %s\n", i+1, cost.Total(variant), form.Format(variant))
+		for _, eq := range nk.ExcludeOther(system, variant) {
+			fmt.Fprintln(out, eq.KString())
 		}
 	}
-}
 
-func ExcludeOther(system []Equation, included []K) []Equation {
-	includedSet := make(map[string]struct{}, len(included))
-	for _, k := range included {
-		includedSet[k.String()] = struct{}{}
+	fmt.Fprintf(out, "result size: %d\n", len(result))
+	fmt.Fprintf(out, "result complexity: %d\n", nk.KS(result).Complexity())
+	resultCost := cost.Total(result)
+	fmt.Fprintf(out, "result cost (%s): %d\n", costModel.Name(), resultCost)
+	bound := nk.LowerBound(toSolve, cost)
+	if constant {
+		bound = resultCost
 	}
-
-	var newSystem []Equation
-	for _, equation := range system {
-		newEquation := Equation{
-			Value: equation.Value,
-		}
-		for _, k := range equation.Coefficients {
-			if _, found := includedSet[k.String()]; found {
-				newEquation.Coefficients = append(newEquation.Coefficients, k)
-			}
-		}
-		newSystem = append(newSystem, newEquation)
+	optimal := "proven optimal"
+	if (mode != nk.Exact || tracker.Err() != nil) && resultCost != bound {
+		optimal = "not proven optimal"
 	}
-	return newSystem
-}
-
+	fmt.Fprintf(out, "lower bound: %d, gap %d, %s\n", bound, resultCost-bound, optimal)
+	fmt.Fprintf(out, "result: %s\n", form.Format(result))
 
-func Format(ks []K) string {
-	var result string
-	for i, k := range ks {
-		if i != 0 {
-			result += " + "
+	if *jsonOutput {
+		encoded, err := json.Marshal(form.Cover(spec.Variables, result).JSON(costModel))
+		if err != nil {
+			fail(err)
 		}
-		result += k.PrettyString()
-	}
-	return result
-}
-
-func main() {
-	f := []int{
-		0, 0, 0, 1, 0, 0, 1, 0, 0, 1, // 00-09
-		0, 1, 0, 1, 1, 1, 1, 0, 1, 1, // 10-19
-		1, 0, 0, 0, 0, 0, 0, 1, 0, 0, // 20-29
-		1, 1, 0, 1, 1, 1, 0, 0, 1, 0, // 30-39
-		0, 1, 0, 1, 1, 1, 0, 1, 1, 0, // 40-49
-		1, 1, 1, 0, 1, 1, 0, 1, 0, 0, // 50-59
-		0, 1, 0, 1,                   // 60-63*/
-	}
-	system := MakeSystemOfEquations(f)
-	system = ExcludeZeroCoefficients(system)
-	fmt.Println("system with ")
-	for _, eq := range system {
-		fmt.Println(eq.KString())
+		fmt.Println(string(encoded))
 	}
-	result := GetMinimalVariant(system, nil)
-
-	fmt.Println("result system:")
-	system = ExcludeOther(system, result)
-	for _, eq := range system {
-		fmt.Println(eq.KString())
-	}
-
-	complexity := KS(result).Complexity()
-	fmt.Printf("result size: %d\n", len(result))
-	fmt.Printf("result complexity: %d\n", complexity)
-	fmt.Printf("result: %s\n", Format(result))
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"github.com/AndreevSemen/asvt/logic/sat"
+	"io"
+	"os"
+	"strings"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+// Функция решает формулу из файла DIMACS и печатает ответ
+func solveDIMACS(path string, tracker *logic.Tracker) error {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	f, err := sat.ParseDIMACS(in)
+	if err != nil {
+		return err
+	}
+	status, model := sat.Solve(f, tracker)
+	fmt.Printf("c %d variables, %d clauses, %d conflicts\n", f.Variables, len(f.Clauses), tracker.Evaluated())
+	if status == sat.Unknown {
+		fmt.Printf("c stopped: %v\n", tracker.Err())
+	}
+	return sat.WriteSolution(os.Stdout, status, model)
+}
+
+// Функция минимизирует ФАЛ двумя алгоритмами и доказывает
+// эквивалентность их покрытий SAT-решателем
+func checkEquivalence(ctx context.Context, spec logic.Spec, backends []string, options logic.Options, tracker *logic.Tracker) error {
+	var covers []logic.Cover
+	for _, backend := range backends {
+		result, err := logic.Minimize(ctx, backend, spec, options)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s\n", backend, result.Cover.PrettyString())
+		covers = append(covers, result.Cover)
+	}
+	equivalent, point, err := sat.Equivalent(covers[0], covers[1], tracker)
+	if err != nil {
+		return err
+	}
+	if equivalent {
+		fmt.Printf("covers are equivalent (%d conflicts)\n", tracker.Evaluated())
+		return nil
+	}
+	// На неопределенных наборах правильные покрытия могут различаться
+	note := ""
+	if spec.Values[point] == logic.DontCare {
+		note = ", the point is undefined"
+	}
+	fmt.Printf("covers differ at %d (%0*b)%s\n", point, spec.Variables, point, note)
+	return nil
+}
+
+// Функция записывает ФАЛ в файл path в формате DIMACS CNF:
+// дизъюнктами СКНФ либо минимальной КНФ
+func exportFunction(ctx context.Context, path string, spec logic.Spec, clauses string, options logic.Options) error {
+	var formula sat.CNF
+	switch clauses {
+	case "sknf":
+		formula = sat.SKNF(spec)
+	case "min":
+		result, err := logic.Minimize(ctx, "nk-cnf", spec, options)
+		if err != nil {
+			return err
+		}
+		formula = sat.FunctionCNF(result.Cover)
+	default:
+		return fmt.Errorf("unknown clauses: %s (expected sknf or min)", clauses)
+	}
+	out := os.Stdout
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	return formula.WriteDIMACS(out)
+}
+
+func main() {
+	dimacs := flag.String("dimacs", "", "DIMACS CNF file to solve (- for stdin)")
+	vector := flag.String("f", "", "truth vector to export or whose covers to check for equivalence, '-' marks don't care")
+	exportPath := flag.String("export", "", "file to write the function given by -f to as DIMACS CNF (- for stdout)")
+	clauses := flag.String("clauses", "sknf", "clauses of the exported function: sknf or min (minimal CNF)")
+	backends := flag.String("equiv", "qmc,nk", "two comma separated minimization algorithms whose covers to compare")
+	var search cli.Search
+	search.Register(flag.CommandLine, "conflicts", false)
+	flag.Parse()
+
+	options, err := search.Options()
+	if err != nil {
+		fail(err)
+	}
+	ctx, stop := cli.Interruptible()
+	defer stop()
+	tracker := logic.NewTracker(ctx, options)
+
+	switch {
+	case *dimacs != "":
+		err = solveDIMACS(*dimacs, tracker)
+	case *vector != "":
+		spec, parseErr := logic.ParseSpec(*vector)
+		if parseErr != nil {
+			fail(parseErr)
+		}
+		if *exportPath != "" {
+			err = exportFunction(ctx, *exportPath, spec, *clauses, options)
+			break
+		}
+		names := strings.Split(*backends, ",")
+		if len(names) != 2 {
+			fail(fmt.Errorf("-equiv needs two algorithms, got %q", *backends))
+		}
+		err = checkEquivalence(ctx, spec, names, options, tracker)
+	default:
+		err = fmt.Errorf("nothing to do: pass -dimacs or -f")
+	}
+	if err != nil {
+		fail(err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/AndreevSemen/asvt/dz1/internal/cli"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"github.com/AndreevSemen/asvt/logic/techmap"
+	"os"
+)
+
+// Функция печатает ошибку во входных данных и завершает программу
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+// Функция минимизирует ФАЛ алгоритмом backend, отображает покрытие на
+// схему функцией mapCover, печатает схему и число микросхем и проверяет
+// схему на всех наборах
+// Возвращает число расхождений
+func report(ctx context.Context, backend string, spec logic.Spec, options logic.Options,
+	mapCover func(logic.Cover, int) (techmap.Netlist, error), fanIn int) int {
+	result, err := logic.Minimize(ctx, backend, spec, options)
+	if err != nil {
+		fail(err)
+	}
+	netlist, err := mapCover(result.Cover, fanIn)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("%s: %s\n", result.Cover.Form, result.Cover.PrettyString())
+	fmt.Printf("%s-%s netlist, fan-in %d:\n", netlist.Op, netlist.Op, fanIn)
+	fmt.Print(netlist)
+	chips, err := netlist.Chips()
+	if err != nil {
+		fail(err)
+	}
+	packages := 0
+	for _, count := range chips {
+		packages += count.Packages
+	}
+	fmt.Printf("gates: %d, levels: %d, chips: %d\n", len(netlist.Gates), netlist.Levels(), packages)
+	for _, count := range chips {
+		fmt.Println("  ", count)
+	}
+	mismatches, err := netlist.Verify(spec)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("verification: %d mismatches on %d points\n", len(mismatches), len(spec.Values))
+	for _, mismatch := range mismatches {
+		fmt.Println("  ", mismatch)
+	}
+	return len(mismatches)
+}
+
+func main() {
+	vector := flag.String("f", "01101000", "truth vector of the function, '-' marks don't care")
+	fanIn := flag.Int("fanin", 2, "largest number of gate inputs: 2 (7400/7402), 3 (7410/7427), 4 (7420/7425) or 8 (7430, NAND only)")
+	nand := flag.Bool("nand", true, "map the minimal DNF to a NAND-NAND network")
+	nor := flag.Bool("nor", true, "map the minimal CNF to a NOR-NOR network")
+	dnfBackend := flag.String("dnf", "qmc", "minimization algorithm for the DNF")
+	cnfBackend := flag.String("cnf", "nk-cnf", "minimization algorithm for the CNF")
+	costName := flag.String("cost", "literals", "cost to minimize: literals, terms, gates, transistors or weights=w0,w1,...")
+	flag.Parse()
+
+	spec, err := logic.ParseSpec(*vector)
+	if err != nil {
+		fail(err)
+	}
+	cost, err := logic.ParseCostModel(*costName)
+	if err != nil {
+		fail(err)
+	}
+	ctx, stop := cli.Interruptible()
+	defer stop()
+	options := logic.Options{Cost: cost}
+
+	mismatches := 0
+	if *nand {
+		mismatches += report(ctx, *dnfBackend, spec, options, techmap.MapNAND, *fanIn)
+	}
+	if *nor {
+		if *nand {
+			fmt.Println()
+		}
+		mismatches += report(ctx, *cnfBackend, spec, options, techmap.MapNOR, *fanIn)
+	}
+	if mismatches != 0 {
+		os.Exit(1)
+	}
+}
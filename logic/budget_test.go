@@ -0,0 +1,51 @@
+package logic_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AndreevSemen/asvt/logic"
+	"testing"
+)
+
+// ФАЛ от 5 переменных, на которой точный перебор не укладывается в 1 узел
+const hardVector = "11000000001010010011101101111101"
+
+// При исчерпании бюджета или отмене каждый алгоритм возвращает верное
+// покрытие, а причину остановки записывает в Stats.Stopped
+func TestMinimizeStopped(t *testing.T) {
+	spec, err := logic.ParseSpec(hardVector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options logic.Options
+		stopped error
+	}{
+		{"budget", context.Background(), logic.Options{Budget: logic.Budget{Nodes: 1}}, logic.ErrBudgetExhausted},
+		{"canceled", canceled, logic.Options{}, context.Canceled},
+	}
+	for _, name := range []string{"qmc", "nk", "nk-cnf"} {
+		for _, test := range tests {
+			result, err := logic.Minimize(test.ctx, name, spec, test.options)
+			if logic.CanceledError(test.stopped) != nil && !errors.Is(err, test.stopped) {
+				t.Errorf("%s (%s): error %v, want %v", name, test.name, err, test.stopped)
+			}
+			if logic.CanceledError(test.stopped) == nil && err != nil {
+				t.Errorf("%s (%s): %v", name, test.name, err)
+			}
+			if mismatches, err := result.Cover.Verify(spec.Values); err != nil || len(mismatches) != 0 {
+				t.Errorf("%s (%s): %s: %v %v", name, test.name, result.Cover.PrettyString(), mismatches, err)
+			}
+			if !errors.Is(result.Stats.Stopped, test.stopped) {
+				t.Errorf("%s (%s): stopped by %v, want %v", name, test.name, result.Stats.Stopped, test.stopped)
+			}
+			if result.Optimal && result.Cost != result.LowerBound {
+				t.Errorf("%s (%s): %s", name, test.name, result.Certificate())
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package logic
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Модель стоимости покрытия, которую минимизируют алгоритмы
+type CostModel interface {
+	// Имя модели, под которым ее выбирает ParseCostModel
+	Name() string
+	// Стоимость покрытия из кубов cubes в форме form
+	Cost(form Form, cubes []Cube) int
+	// Вклад одного куба в стоимость покрытия
+	// Для аддитивных моделей стоимость покрытия равна сумме вкладов кубов,
+	// для остальных не меньше нее, поэтому сумма вкладов годится
+	// для нижних оценок при отсечении перебора
+	CubeCost(form Form, cube Cube) int
+	// Функция сообщает, равна ли стоимость покрытия сумме вкладов кубов
+	// Только для аддитивных моделей замена куба более дешевым, решающим
+	// те же задачи, не увеличивает стоимость
+	Additive() bool
+}
+
+// Функция складывает вклады кубов в стоимость
+func sumCubeCosts(model CostModel, form Form, cubes []Cube) int {
+	cost := 0
+	for _, cube := range cubes {
+		cost += model.CubeCost(form, cube)
+	}
+	return cost
+}
+
+// Стоимость - количество литералов
+type LiteralCost struct{}
+
+func (LiteralCost) Name() string { return "literals" }
+
+func (m LiteralCost) Cost(form Form, cubes []Cube) int { return sumCubeCosts(m, form, cubes) }
+
+func (LiteralCost) CubeCost(form Form, cube Cube) int { return cube.Literals() }
+
+func (LiteralCost) Additive() bool { return true }
+
+// Стоимость - количество конъюнкций (дизъюнктов)
+type TermCost struct{}
+
+func (TermCost) Name() string { return "terms" }
+
+func (m TermCost) Cost(form Form, cubes []Cube) int { return sumCubeCosts(m, form, cubes) }
+
+func (TermCost) CubeCost(form Form, cube Cube) int { return 1 }
+
+func (TermCost) Additive() bool { return true }
+
+// Стоимость - количество входов вентилей: литералы первого уровня
+// и по одному входу второго уровня на каждую конъюнкцию
+type GateInputCost struct{}
+
+func (GateInputCost) Name() string { return "gates" }
+
+func (m GateInputCost) Cost(form Form, cubes []Cube) int { return sumCubeCosts(m, form, cubes) }
+
+func (GateInputCost) CubeCost(form Form, cube Cube) int { return cube.Literals() + 1 }
+
+func (GateInputCost) Additive() bool { return true }
+
+// Оценка числа транзисторов КМОП-реализации в базисе И-НЕ/И-НЕ
+// (ИЛИ-НЕ/ИЛИ-НЕ для КНФ): вентиль на k входов - 2k транзисторов,
+// конъюнкция из одного литерала и форма из одной конъюнкции вентиля
+// не требуют, каждая инвертируемая переменная - инвертор из 2 транзисторов
+// Инверторы общие для всех конъюнкций, поэтому модель не аддитивна
+type TransistorCost struct{}
+
+func (TransistorCost) Name() string { return "transistors" }
+
+func (m TransistorCost) Cost(form Form, cubes []Cube) int {
+	cost := sumCubeCosts(m, form, cubes)
+	if len(cubes) > 1 {
+		cost += 2 * len(cubes)
+	}
+	var complemented uint32
+	for _, cube := range cubes {
+		complemented |= cube.Complemented(form)
+	}
+	return cost + 2*bits.OnesCount32(complemented)
+}
+
+func (TransistorCost) CubeCost(form Form, cube Cube) int {
+	if cube.Literals() < 2 {
+		return 0
+	}
+	return 2 * cube.Literals()
+}
+
+func (TransistorCost) Additive() bool { return false }
+
+// Стоимость - сумма весов переменных по всем литералам
+// Weights[i] - вес переменной x_i; переменные без веса стоят 1
+type WeightedCost struct {
+	Weights []int
+}
+
+func (WeightedCost) Name() string { return "weights" }
+
+func (m WeightedCost) Cost(form Form, cubes []Cube) int { return sumCubeCosts(m, form, cubes) }
+
+func (WeightedCost) Additive() bool { return true }
+
+func (m WeightedCost) CubeCost(form Form, cube Cube) int {
+	cost := 0
+	for mask := cube.Mask; mask != 0; mask &= mask - 1 {
+		i := bits.TrailingZeros32(mask)
+		if i < len(m.Weights) {
+			cost += m.Weights[i]
+		} else {
+			cost++
+		}
+	}
+	return cost
+}
+
+// Модель стоимости по умолчанию
+var DefaultCost CostModel = LiteralCost{}
+
+// Функция выбирает модель стоимости по имени: literals, terms, gates,
+// transistors или weights=w0,w1,... с весами переменных x0, x1, ...
+func ParseCostModel(s string) (CostModel, error) {
+	switch s {
+	case "", "literals":
+		return LiteralCost{}, nil
+	case "terms":
+		return TermCost{}, nil
+	case "gates":
+		return GateInputCost{}, nil
+	case "transistors":
+		return TransistorCost{}, nil
+	}
+	if strings.HasPrefix(s, "weights=") {
+		var model WeightedCost
+		for _, field := range strings.Split(strings.TrimPrefix(s, "weights="), ",") {
+			weight, err := strconv.Atoi(field)
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("bad variable weight %q", field)
+			}
+			model.Weights = append(model.Weights, weight)
+		}
+		return model, nil
+	}
+	return nil, fmt.Errorf("unknown cost model: %s (expected literals, terms, gates, transistors or weights=...)", s)
+}
+
+// Функция возвращает стоимость покрытия в модели model
+func (c Cover) Cost(model CostModel) int {
+	return model.Cost(c.Form, c.Cubes)
+}
+
+// Модель стоимости со штрафом Penalty за каждую переменную, которая входит
+// в покрытие с инверсией: инвертор ставится один на все покрытие,
+// поэтому штраф не входит во вклады кубов
+type ComplementPenalty struct {
+	Base    CostModel
+	Penalty int
+}
+
+func (m ComplementPenalty) Name() string {
+	return fmt.Sprintf("%s+%d per complemented variable", m.Base.Name(), m.Penalty)
+}
+
+func (m ComplementPenalty) Cost(form Form, cubes []Cube) int {
+	var complemented uint32
+	for _, cube := range cubes {
+		complemented |= cube.Complemented(form)
+	}
+	return m.Base.Cost(form, cubes) + m.Penalty*bits.OnesCount32(complemented)
+}
+
+func (m ComplementPenalty) CubeCost(form Form, cube Cube) int {
+	return m.Base.CubeCost(form, cube)
+}
+
+func (m ComplementPenalty) Additive() bool { return m.Penalty == 0 && m.Base.Additive() }
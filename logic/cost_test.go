@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"testing"
+)
+
+func TestCostModels(t *testing.T) {
+	// x1!x0 + x2 и (x1 + !x0)x2 на тех же кубах
+	cubes := []Cube{{Mask: 3, Values: 2}, {Mask: 4, Values: 4}}
+	tests := []struct {
+		model    CostModel
+		form     Form
+		cubes    []Cube
+		cost     int
+		additive bool
+	}{
+		{LiteralCost{}, DNF, cubes, 3, true},
+		{TermCost{}, DNF, cubes, 2, true},
+		{GateInputCost{}, DNF, cubes, 5, true},
+		// Вентиль И на 2 входа, ИЛИ на 2 входа и инвертор x0
+		{TransistorCost{}, DNF, cubes, 4 + 4 + 2, false},
+		// В КНФ инвертируются x1 и x2
+		{TransistorCost{}, CNF, cubes, 4 + 4 + 4, false},
+		{TransistorCost{}, DNF, []Cube{{Mask: 1, Values: 1}}, 0, false},
+		{TransistorCost{}, DNF, []Cube{{Mask: 1}}, 2, false},
+		{WeightedCost{Weights: []int{5, 1}}, DNF, cubes, 5 + 1 + 1, true},
+		{WeightedCost{}, DNF, cubes, 3, true},
+		{ComplementPenalty{Base: LiteralCost{}, Penalty: 10}, DNF, cubes, 3 + 10, false},
+		{ComplementPenalty{Base: LiteralCost{}, Penalty: 10}, CNF, cubes, 3 + 20, false},
+		{ComplementPenalty{Base: TermCost{}}, DNF, cubes, 2, true},
+		{LiteralCost{}, DNF, nil, 0, true},
+	}
+	for _, test := range tests {
+		if got := test.model.Cost(test.form, test.cubes); got != test.cost {
+			t.Errorf("%s %s %v: cost %d, want %d", test.model.Name(), test.form, test.cubes, got, test.cost)
+		}
+		if test.model.Additive() != test.additive {
+			t.Errorf("%s: additive %t, want %t", test.model.Name(), test.model.Additive(), test.additive)
+		}
+		// Сумма вкладов кубов - нижняя оценка, точная для аддитивных моделей
+		sum := sumCubeCosts(test.model, test.form, test.cubes)
+		if sum > test.cost || test.additive && sum != test.cost {
+			t.Errorf("%s %s %v: cube costs sum to %d, cost %d", test.model.Name(), test.form, test.cubes, sum, test.cost)
+		}
+	}
+}
+
+func TestParseCostModel(t *testing.T) {
+	tests := []struct {
+		s    string
+		name string
+		ok   bool
+	}{
+		{"", "literals", true},
+		{"literals", "literals", true},
+		{"terms", "terms", true},
+		{"gates", "gates", true},
+		{"transistors", "transistors", true},
+		{"weights=1,2,3", "weights", true},
+		{"weights=", "", false},
+		{"weights=1,-2", "", false},
+		{"weights=1,a", "", false},
+		{"area", "", false},
+	}
+	for _, test := range tests {
+		model, err := ParseCostModel(test.s)
+		if (err == nil) != test.ok {
+			t.Errorf("ParseCostModel(%q): error %v", test.s, err)
+			continue
+		}
+		if test.ok && model.Name() != test.name {
+			t.Errorf("ParseCostModel(%q) = %s, want %s", test.s, model.Name(), test.name)
+		}
+	}
+	model, err := ParseCostModel("weights=4,0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weighted, ok := model.(WeightedCost); !ok || len(weighted.Weights) != 2 || weighted.Weights[0] != 4 || weighted.Weights[1] != 0 {
+		t.Errorf("ParseCostModel(weights=4,0) = %#v", model)
+	}
+}
@@ -0,0 +1,224 @@
+package logic
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// Нормальная форма покрытия
+type Form int
+
+const (
+	// Дизъюнкция конъюнкций: кубы покрывают единичные наборы
+	DNF Form = iota
+	// Конъюнкция дизъюнкций: кубы покрывают нулевые наборы,
+	// на которых соответствующий дизъюнкт обращается в ноль
+	CNF
+	// Сумма по модулю 2 конъюнкций (ESOP): функция равна 1 на наборах,
+	// которые входят в нечетное число кубов
+	ESOP
+)
+
+func (form Form) String() string {
+	switch form {
+	case CNF:
+		return "cnf"
+	case ESOP:
+		return "esop"
+	}
+	return "dnf"
+}
+
+// Куб - общее представление импликанты или коэффициента для всех алгоритмов
+// Бит i маски Mask означает, что переменная x_i входит в куб, а бит i маски
+// Values хранит ее значение; x0 соответствует старшему разряду номера набора
+type Cube struct {
+	Mask   uint32
+	Values uint32
+}
+
+// Функция возвращает количество литералов куба
+func (c Cube) Literals() int {
+	return bits.OnesCount32(c.Mask)
+}
+
+// Функция возвращает маску переменных, входящих в куб с инверсией
+// В ДНФ инвертированы переменные со значением 0, а в дизъюнкте КНФ,
+// который обращается в ноль на наборах куба, - со значением 1
+func (c Cube) Complemented(form Form) uint32 {
+	if form == CNF {
+		return c.Values & c.Mask
+	}
+	return c.Mask &^ c.Values
+}
+
+// Функция переводит номер набора в маску значений переменных
+func PointValues(point, n int) uint32 {
+	var values uint32
+	for i := 0; i < n; i++ {
+		if point&(1<<uint(n-1-i)) != 0 {
+			values |= 1 << uint(i)
+		}
+	}
+	return values
+}
+
+// Функция проверяет, входит ли в куб набор с номером point функции от n переменных
+func (c Cube) Contains(point, n int) bool {
+	return PointValues(point, n)&c.Mask == c.Values
+}
+
+// Функция преобразования куба в конъюнкцию
+// Переменные печатаются от старшей к младшей, как в qmc и nk: "x5x2!x1"
+// Куб без литералов - константа 1
+func (c Cube) PrettyString() string {
+	if c.Mask == 0 {
+		return "1"
+	}
+	var formatted string
+	for i := 31; i >= 0; i-- {
+		bit := uint32(1) << uint(i)
+		if c.Mask&bit == 0 {
+			continue
+		}
+		if c.Values&bit == 0 {
+			formatted += "!"
+		}
+		formatted += "x" + strconv.Itoa(i)
+	}
+	return formatted
+}
+
+// Функция преобразования куба нулевых наборов в дизъюнкт: "(!x5 + x2)"
+func (c Cube) ClauseString() string {
+	var formatted string
+	for i := 31; i >= 0; i-- {
+		bit := uint32(1) << uint(i)
+		if c.Mask&bit == 0 {
+			continue
+		}
+		if formatted != "" {
+			formatted += " + "
+		}
+		if c.Values&bit != 0 {
+			formatted += "!"
+		}
+		formatted += "x" + strconv.Itoa(i)
+	}
+	if c.Literals() > 1 {
+		formatted = "(" + formatted + ")"
+	}
+	// Дизъюнкт без литералов - константа 0
+	if c.Mask == 0 {
+		formatted = "0"
+	}
+	return formatted
+}
+
+// Функция записывает куб строкой, в которой символ i соответствует x_i:
+// 0 и 1 - значение переменной, '-' - переменная не входит в куб
+func (c Cube) Notation(n int) string {
+	var formatted string
+	for i := 0; i < n; i++ {
+		bit := uint32(1) << uint(i)
+		switch {
+		case c.Mask&bit == 0:
+			formatted += "-"
+		case c.Values&bit != 0:
+			formatted += "1"
+		default:
+			formatted += "0"
+		}
+	}
+	return formatted
+}
+
+// Дизъюнкт КНФ, который печатается в виде дизъюнкции
+type Clause Cube
+
+func (c Clause) Contains(point, n int) bool {
+	return Cube(c).Contains(point, n)
+}
+
+func (c Clause) PrettyString() string {
+	return Cube(c).ClauseString()
+}
+
+// Покрытие - результат минимизации в виде ДНФ, КНФ либо ESOP
+type Cover struct {
+	Variables int
+	Form      Form
+	Cubes     []Cube
+}
+
+// Функция возвращает количество литералов покрытия
+func (c Cover) Literals() int {
+	literals := 0
+	for _, cube := range c.Cubes {
+		literals += cube.Literals()
+	}
+	return literals
+}
+
+// Функция форматирует покрытие в ДНФ, КНФ либо ESOP: "x1!x0 ^ x2"
+// Константы записываются как 0 и 1: пустая дизъюнкция и сумма по модулю 2 -
+// 0, пустая конъюнкция - 1, куб без литералов - 1, а дизъюнкт без литералов - 0
+func (c Cover) PrettyString() string {
+	if len(c.Cubes) == 0 {
+		if c.Form == CNF {
+			return "1"
+		}
+		return "0"
+	}
+	var formatted string
+	for i, cube := range c.Cubes {
+		switch {
+		case c.Form == CNF:
+			formatted += cube.ClauseString()
+		case c.Form == ESOP:
+			if i != 0 {
+				formatted += " ^ "
+			}
+			formatted += cube.PrettyString()
+		default:
+			if i != 0 {
+				formatted += " + "
+			}
+			formatted += cube.PrettyString()
+		}
+	}
+	return formatted
+}
+
+// Функция преобразует кубы для проверки через VerifyDNF, VerifyCNF
+// и VerifyESOP
+func (c Cover) Implicants() []Implicant {
+	implicants := make([]Implicant, 0, len(c.Cubes))
+	for _, cube := range c.Cubes {
+		if c.Form == CNF {
+			implicants = append(implicants, Clause(cube))
+		} else {
+			implicants = append(implicants, cube)
+		}
+	}
+	return implicants
+}
+
+// Функция проверяет покрытие на всех наборах функции f
+// Число переменных покрытия должно совпадать с числом переменных f
+func (c Cover) Verify(f []int) ([]Mismatch, error) {
+	variables, err := Arity(f)
+	if err != nil {
+		return nil, err
+	}
+	if variables != c.Variables {
+		return nil, &ArityError{Expected: variables, Actual: c.Variables}
+	}
+	switch c.Form {
+	case CNF:
+		return VerifyCNF(f, c.Implicants())
+	case ESOP:
+		return VerifyESOP(f, c.Implicants())
+	}
+	return VerifyDNF(f, c.Implicants())
+}
@@ -0,0 +1,29 @@
+package logic
+
+import "testing"
+
+func TestCoverPrettyString(t *testing.T) {
+	x0 := Cube{Mask: 1, Values: 1}
+	notX1 := Cube{Mask: 2}
+	tests := []struct {
+		form  Form
+		cubes []Cube
+		want  string
+	}{
+		{DNF, nil, "0"},
+		{DNF, []Cube{{}}, "1"},
+		{DNF, []Cube{x0, notX1}, "x0 + !x1"},
+		{DNF, []Cube{{Mask: 3, Values: 1}}, "!x1x0"},
+		{CNF, nil, "1"},
+		{CNF, []Cube{{}}, "0"},
+		{CNF, []Cube{x0, {Mask: 3, Values: 1}}, "!x0(x1 + !x0)"},
+		{ESOP, nil, "0"},
+		{ESOP, []Cube{{}, x0}, "1 ^ x0"},
+	}
+	for _, test := range tests {
+		cover := Cover{Variables: 2, Form: test.form, Cubes: test.cubes}
+		if got := cover.PrettyString(); got != test.want {
+			t.Errorf("%s %v: %q, want %q", test.form, test.cubes, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,540 @@
+package decompose
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"strconv"
+)
+
+// Вентиль, объединяющий две функции при бидекомпозиции
+type Operation int
+
+const (
+	None Operation = iota
+	And
+	Or
+	Xor
+)
+
+func (op Operation) String() string {
+	switch op {
+	case And:
+		return "and"
+	case Or:
+		return "or"
+	case Xor:
+		return "xor"
+	}
+	return "none"
+}
+
+// Функция возвращает значение вентиля на входах a и b
+func (op Operation) apply(a, b int) int {
+	switch op {
+	case And:
+		return a & b
+	case Or:
+		return a | b
+	}
+	return a ^ b
+}
+
+// Функция записывает вентиль от двух выражений: "ab", "a + b", "a ^ b"
+func (op Operation) join(a, b string) string {
+	switch op {
+	case And:
+		return a + b
+	case Or:
+		return a + " + " + b
+	}
+	return a + " ^ " + b
+}
+
+// Функция возвращает таблицу истинности вентиля как функцию двух переменных
+func (op Operation) spec() logic.Spec {
+	values := make([]int, 4)
+	for point := range values {
+		values[point] = op.apply(point>>1, point&1)
+	}
+	return logic.NewSpec(values)
+}
+
+// Не полностью определенная функция в интервальной форме: единичные
+// наборы On и нулевые Off на всех 2^n наборах исходной функции
+type interval struct {
+	on, off []bool
+}
+
+// Функция возвращает маску переменной v в номере набора функции от n переменных
+func variableBit(n, v int) int {
+	return 1 << uint(n-1-v)
+}
+
+// Функция возвращает ∃X s - наборы, отличающиеся от какого-либо набора s
+// только значениями переменных X
+func exists(s []bool, n int, variables []int) []bool {
+	result := append([]bool(nil), s...)
+	for _, v := range variables {
+		bit := variableBit(n, v)
+		for point := range result {
+			if result[point^bit] {
+				result[point] = true
+			}
+		}
+	}
+	return result
+}
+
+// Функция возвращает пересечение множеств наборов
+func intersect(a, b []bool) []bool {
+	result := make([]bool, len(a))
+	for point := range a {
+		result[point] = a[point] && b[point]
+	}
+	return result
+}
+
+// Функция сообщает, что множества наборов не пересекаются
+func disjoint(a, b []bool) bool {
+	for point := range a {
+		if a[point] && b[point] {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция возвращает переменные variables, которых нет в other
+func without(variables, other []int) []int {
+	var result []int
+	for _, v := range variables {
+		found := false
+		for _, o := range other {
+			found = found || o == v
+		}
+		if !found {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Функция решает f = g(A, C) ^ h(B, C): каждый определенный набор
+// задает четность g ^ h, и система совместна, если в системе уравнений
+// нет противоречивого цикла (система непересекающихся множеств с четностью)
+// Возвращает значения g на наборах с нулевыми B; -1, если g не ограничена
+func xorSolve(f interval, n int, a, b []int) ([]int, bool) {
+	var aMask, bMask int
+	for _, v := range a {
+		aMask |= variableBit(n, v)
+	}
+	for _, v := range b {
+		bMask |= variableBit(n, v)
+	}
+	// Вершины 0..2^n-1 - значения g на наборе без B,
+	// вершины 2^n.. - значения h на наборе без A
+	size := len(f.on)
+	parent := make([]int, 2*size)
+	parity := make([]int, 2*size)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(x int) (int, int)
+	find = func(x int) (int, int) {
+		if parent[x] == x {
+			return x, 0
+		}
+		root, p := find(parent[x])
+		parent[x] = root
+		parity[x] ^= p
+		return root, parity[x]
+	}
+	constrained := make([]bool, size)
+	for point := range f.on {
+		if !f.on[point] && !f.off[point] {
+			continue
+		}
+		value := 0
+		if f.on[point] {
+			value = 1
+		}
+		g, h := point&^bMask, size+point&^aMask
+		constrained[g] = true
+		rootG, pg := find(g)
+		rootH, ph := find(h)
+		if rootG == rootH {
+			if pg^ph != value {
+				return nil, false
+			}
+			continue
+		}
+		parent[rootG] = rootH
+		parity[rootG] = pg ^ ph ^ value
+	}
+	values := make([]int, size)
+	for point := range values {
+		values[point] = -1
+		if g := point &^ bMask; constrained[g] {
+			_, p := find(g)
+			values[point] = p
+		}
+	}
+	return values, true
+}
+
+// Функция проверяет, раскладывается ли f = g(A, C) op h(B, C)
+// Для ИЛИ нулевые наборы g и h - ∃B Off и ∃A Off, поэтому единичные
+// наборы, не покрытые ни одной из них, - Q ∧ ∃A R ∧ ∃B R; для И -
+// двойственно; для XOR решается система уравнений xorSolve
+func decomposable(f interval, n int, op Operation, a, b []int) bool {
+	switch op {
+	case Or:
+		return disjoint(intersect(f.on, exists(f.off, n, a)), exists(f.off, n, b))
+	case And:
+		return disjoint(intersect(f.off, exists(f.on, n, a)), exists(f.on, n, b))
+	}
+	_, found := xorSolve(f, n, a, b)
+	return found
+}
+
+// Бидекомпозиция f = g(A ∪ C) op h(B ∪ C)
+type split struct {
+	op   Operation
+	a, b []int
+}
+
+// Состояние рекурсивной бидекомпозиции
+type bidecomposer struct {
+	ctx       context.Context
+	backend   string
+	options   logic.Options
+	n         int
+	functions int
+}
+
+// Функция исключает переменные, от которых f можно сделать независимой:
+// ∃x On и ∃x Off не пересекаются
+func (d *bidecomposer) reduce(f interval, support []int) (interval, []int) {
+	var kept []int
+	for _, v := range support {
+		on, off := exists(f.on, d.n, []int{v}), exists(f.off, d.n, []int{v})
+		if disjoint(on, off) {
+			f = interval{on, off}
+			continue
+		}
+		kept = append(kept, v)
+	}
+	return f, kept
+}
+
+// Функция ищет бидекомпозицию с наименьшим числом общих переменных C,
+// затем с наименьшим большим из A и B: начиная с каждой пары переменных,
+// жадно добавляет остальные переменные к меньшему из множеств A и B
+func (d *bidecomposer) find(f interval, support []int) (split, bool) {
+	var best split
+	found := false
+	size := func(s split) (int, int) {
+		common := len(support) - len(s.a) - len(s.b)
+		larger := len(s.a)
+		if len(s.b) > larger {
+			larger = len(s.b)
+		}
+		return common, larger
+	}
+	for _, op := range []Operation{And, Or, Xor} {
+		for i, x := range support {
+			for _, y := range support[i+1:] {
+				s := split{op, []int{x}, []int{y}}
+				if !decomposable(f, d.n, op, s.a, s.b) {
+					continue
+				}
+				for _, v := range support {
+					if v == x || v == y {
+						continue
+					}
+					first, second := &s.a, &s.b
+					if len(s.b) < len(s.a) {
+						first, second = second, first
+					}
+					for _, side := range []*[]int{first, second} {
+						*side = append(*side, v)
+						if decomposable(f, d.n, op, s.a, s.b) {
+							break
+						}
+						*side = (*side)[:len(*side)-1]
+					}
+				}
+				if !found {
+					best, found = s, true
+					continue
+				}
+				common, larger := size(s)
+				bestCommon, bestLarger := size(best)
+				if common < bestCommon || common == bestCommon && larger < bestLarger {
+					best = s
+				}
+			}
+		}
+	}
+	return best, found
+}
+
+// Функция строит лист name: функцию от переменных support,
+// минимизированную алгоритмом d.backend
+func (d *bidecomposer) leaf(f interval, support []int, name string) (*Node, error) {
+	values := make([]int, 1<<uint(len(support)))
+	for local := range values {
+		point := 0
+		for i, v := range support {
+			if local&(1<<uint(len(support)-1-i)) != 0 {
+				point |= variableBit(d.n, v)
+			}
+		}
+		switch {
+		case f.on[point]:
+			values[local] = 1
+		case f.off[point]:
+			values[local] = 0
+		default:
+			values[local] = logic.DontCare
+		}
+	}
+	node := &Node{Name: name, Variable: -1, Spec: logic.NewSpec(values)}
+	for _, v := range support {
+		node.Inputs = append(node.Inputs, Variable(v))
+	}
+	result, err := logic.Minimize(d.ctx, d.backend, node.Spec, d.options)
+	if err != nil {
+		return nil, err
+	}
+	node.Cover = &result.Cover
+	return node, nil
+}
+
+func (d *bidecomposer) name() string {
+	d.functions++
+	return "g" + strconv.Itoa(d.functions)
+}
+
+// Функция возвращает значения поддерева на всех наборах исходной функции
+func (d *bidecomposer) values(node *Node) []int {
+	values := make([]int, 1<<uint(d.n))
+	for point := range values {
+		values[point] = node.Value(point, d.n)
+	}
+	return values
+}
+
+// Функция рекурсивно раскладывает f: сначала строится g по интервалу,
+// выведенному из f, затем h - по f и построенной g
+// ИЛИ: g ∈ [∃B(Q ∧ ∃A R), ¬∃B R], h ∈ [∃A(Q ∧ ¬g), ¬∃A R]
+// И:   g ∈ [∃B Q, ¬∃B(R ∧ ∃A Q)], h ∈ [∃A Q, ¬∃A(R ∧ g)]
+// XOR: g - решение xorSolve, h ∈ [∃A(f ^ g = 1), ¬∃A(f ^ g = 0)]
+// Если двухуровневая схема функции не больше схемы разложения,
+// узел остается листом
+func (d *bidecomposer) build(f interval, support []int, name string) (*Node, error) {
+	// Исключение переменных жадное, и при неопределенных наборах оно может
+	// оставить не те переменные, поэтому лист строится и по исходной функции
+	leaf, err := d.leaf(f, support, name)
+	if err != nil {
+		return nil, err
+	}
+	reduced, kept := d.reduce(f, support)
+	if len(kept) < len(support) {
+		small, err := d.leaf(reduced, kept, name)
+		if err != nil {
+			return nil, err
+		}
+		if !smaller(leaf, small) {
+			leaf = small
+		}
+	}
+	f, support = reduced, kept
+	s, found := split{}, false
+	if len(support) > 1 {
+		s, found = d.find(f, support)
+	}
+	if !found {
+		return leaf, nil
+	}
+	functions := d.functions
+	common := without(support, append(append([]int(nil), s.a...), s.b...))
+	var g interval
+	switch s.op {
+	case Or:
+		g = interval{exists(intersect(f.on, exists(f.off, d.n, s.a)), d.n, s.b), exists(f.off, d.n, s.b)}
+	case And:
+		g = interval{exists(f.on, d.n, s.b), exists(intersect(f.off, exists(f.on, d.n, s.a)), d.n, s.b)}
+	case Xor:
+		solution, _ := xorSolve(f, d.n, s.a, s.b)
+		g = interval{make([]bool, len(solution)), make([]bool, len(solution))}
+		for point, value := range solution {
+			g.on[point] = value == 1
+			g.off[point] = value == 0
+		}
+	}
+	left, err := d.build(g, append(append([]int(nil), s.a...), common...), d.name())
+	if err != nil {
+		return nil, err
+	}
+	gValues := d.values(left)
+	h := interval{make([]bool, len(gValues)), make([]bool, len(gValues))}
+	for point, value := range gValues {
+		switch s.op {
+		case Or:
+			h.on[point] = f.on[point] && value == 0
+			h.off[point] = f.off[point]
+		case And:
+			h.on[point] = f.on[point]
+			h.off[point] = f.off[point] && value == 1
+		case Xor:
+			h.on[point] = f.on[point] && value == 0 || f.off[point] && value == 1
+			h.off[point] = f.off[point] && value == 0 || f.on[point] && value == 1
+		}
+	}
+	h = interval{exists(h.on, d.n, s.a), exists(h.off, d.n, s.a)}
+	right, err := d.build(h, append(append([]int(nil), s.b...), common...), d.name())
+	if err != nil {
+		return nil, err
+	}
+	node := &Node{Name: name, Variable: -1, Operation: s.op, Inputs: []*Node{left, right}, Spec: s.op.spec()}
+
+	// Разложение оставляется, только если его схема не больше
+	// двухуровневой схемы той же функции
+	if smaller(leaf, node) {
+		d.functions = functions
+		return leaf, nil
+	}
+	return node, nil
+}
+
+// Функция сообщает, что в схеме a меньше вентилей, чем в b, а при равном
+// числе вентилей - меньше их входов
+func smaller(a, b *Node) bool {
+	gates, inputs := a.Gates()
+	otherGates, otherInputs := b.Gates()
+	return gates < otherGates || gates == otherGates && inputs < otherInputs
+}
+
+// Функция рекурсивно раскладывает spec в схему из вентилей И, ИЛИ и XOR
+// с двумя входами: f = g(X1) op h(X2), где X1 и X2 могут пересекаться;
+// функции, которые не раскладываются или разложение которых не уменьшает
+// числа вентилей, минимизируются алгоритмом backend
+// Корень схемы называется f, остальные узлы - g1, g2, ...
+func Bidecompose(ctx context.Context, spec logic.Spec, backend string, options logic.Options) (*Node, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	f := interval{make([]bool, len(spec.Values)), make([]bool, len(spec.Values))}
+	for point, value := range spec.Values {
+		f.on[point] = value == 1
+		f.off[point] = value == 0
+	}
+	support := make([]int, spec.Variables)
+	for i := range support {
+		support[i] = i
+	}
+	d := &bidecomposer{ctx: ctx, backend: backend, options: options, n: spec.Variables}
+	return d.build(f, support, "f")
+}
+
+// Функция возвращает число вентилей двухуровневой схемы покрытия и число
+// их входов: вентиль на каждый куб из двух и более литералов и выходной
+// вентиль, если кубов больше одного; инверторы входов не считаются
+func TwoLevelGates(cover logic.Cover) (gates, inputs int) {
+	for _, cube := range cover.Cubes {
+		if literals := cube.Literals(); literals > 1 {
+			gates++
+			inputs += literals
+		}
+	}
+	if len(cover.Cubes) > 1 {
+		gates++
+		inputs += len(cover.Cubes)
+	}
+	return gates, inputs
+}
+
+// Функция возвращает число вентилей схемы и число их входов: вентили узлов
+// бидекомпозиции и двухуровневые схемы минимизированных функций остальных
+// узлов; узлы без покрытия не считаются
+func (n *Node) Gates() (gates, inputs int) {
+	for _, node := range n.Nodes() {
+		switch {
+		case node.Operation != None:
+			gates++
+			inputs += len(node.Inputs)
+		case node.Cover != nil:
+			g, i := TwoLevelGates(*node.Cover)
+			gates += g
+			inputs += i
+		}
+	}
+	return gates, inputs
+}
+
+// Функция записывает схему одной формулой, подставляя выражения узлов
+// вместо их имен: "(x0 + x1)(x2 ^ x3)"
+func (n *Node) Formula() string {
+	formula, _ := n.formula()
+	return formula
+}
+
+// Функция возвращает формулу узла и операцию ее верхнего уровня;
+// None - литерал или константа
+func (n *Node) formula() (string, Operation) {
+	if n.IsVariable() {
+		return n.Name, None
+	}
+	if n.Operation == None {
+		expression := n.Expression()
+		switch {
+		case n.Cover == nil || len(n.Cover.Cubes) == 0:
+			return expression, None
+		case len(n.Cover.Cubes) > 1 && n.Cover.Form == logic.DNF:
+			return expression, Or
+		case len(n.Cover.Cubes) > 1 && n.Cover.Form == logic.ESOP:
+			return expression, Xor
+		case len(n.Cover.Cubes) > 1 || n.Cover.Cubes[0].Literals() > 1:
+			return expression, And
+		}
+		return expression, None
+	}
+	var operands []string
+	for _, input := range n.Inputs {
+		operand, op := input.formula()
+		// Конъюнкция записывается подряд и связывает сильнее всего,
+		// остальные операции берутся в скобки, если отличаются от операции узла
+		if op != None && op != And && op != n.Operation {
+			operand = "(" + operand + ")"
+		}
+		operands = append(operands, operand)
+	}
+	return n.Operation.join(operands[0], operands[1]), n.Operation
+}
+
+// Функция возвращает наибольшее число вентилей на пути от входа к выходу
+// схемы; двухуровневая функция узла считается за два уровня
+func (n *Node) Depth() int {
+	if n.IsVariable() {
+		return 0
+	}
+	own := 1
+	if n.Operation == None {
+		own = 0
+		if n.Cover != nil {
+			g, _ := TwoLevelGates(*n.Cover)
+			own = g
+			if own > 2 {
+				own = 2
+			}
+		}
+	}
+	depth := 0
+	for _, input := range n.Inputs {
+		if d := input.Depth(); d > depth {
+			depth = d
+		}
+	}
+	return depth + own
+}
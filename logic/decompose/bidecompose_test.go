@@ -0,0 +1,108 @@
+package decompose
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/esop"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"math/rand"
+	"testing"
+)
+
+// Функция раскладывает f и проверяет схему; схема не больше двухуровневой
+// схемы покрытия, которое backend находит для всей функции
+func bidecompose(t *testing.T, f []int, backend string) *Node {
+	t.Helper()
+	spec := logic.NewSpec(f)
+	root, err := Bidecompose(context.Background(), spec, backend, logic.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mismatches, _ := root.Verify(spec); len(mismatches) != 0 {
+		t.Fatalf("%s %v:\n%s%v", backend, f, root.PrettyString(), mismatches)
+	}
+	result, err := logic.Minimize(context.Background(), backend, spec, logic.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gates, inputs := root.Gates()
+	leafGates, leafInputs := TwoLevelGates(result.Cover)
+	if gates > leafGates || gates == leafGates && inputs > leafInputs {
+		t.Errorf("%s %v: %d gates with %d inputs, two-level %d with %d:\n%s",
+			backend, f, gates, inputs, leafGates, leafInputs, root.PrettyString())
+	}
+	return root
+}
+
+func TestBidecomposeExhaustive(t *testing.T) {
+	for n := 1; n <= 3; n++ {
+		logictest.ForEachFunction(n, true, func(f []int) {
+			bidecompose(t, f, "qmc")
+		})
+	}
+	logictest.ForEachFunction(3, false, func(f []int) {
+		bidecompose(t, f, "esop")
+	})
+}
+
+func TestBidecomposeRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 4 + random.Intn(3)
+		f := make([]int, 1<<uint(n))
+		for point := range f {
+			f[point] = random.Intn(3)
+			if f[point] == 2 {
+				f[point] = logic.DontCare
+			}
+		}
+		bidecompose(t, f, "qmc")
+	}
+}
+
+func TestBidecompose(t *testing.T) {
+	tests := []struct {
+		vector               string
+		formula              string
+		gates, inputs, depth int
+	}{
+		{"0110100110010110", "!x0 ^ !x2 ^ !x1 ^ !x3", 3, 6, 2},
+		{"0001000100011111", "x0x1 + x2x3", 3, 6, 2},
+		{"0111111111111111", "x3 + x2 + x1 + x0", 1, 4, 1},
+		// x0 и мажоритарная функция остальных: двухуровневая схема меньше
+		{"0000000000010111", "x0x2x3 + x0x1x3 + x0x1x2", 4, 12, 2},
+		{"00110101", "(x1 + x0)(x2 + !x0)", 3, 6, 2},
+		// Неопределенные наборы позволяют оставить одну переменную x0
+		{"000----1", "x0", 0, 0, 0},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := bidecompose(t, spec.Values, "qmc")
+		gates, inputs := root.Gates()
+		if root.Formula() != test.formula || gates != test.gates || inputs != test.inputs || root.Depth() != test.depth {
+			t.Errorf("%s: %s, %d gates with %d inputs, depth %d", test.vector, root.Formula(), gates, inputs, root.Depth())
+		}
+	}
+}
+
+func TestTwoLevelGates(t *testing.T) {
+	tests := []struct {
+		cover         logic.Cover
+		gates, inputs int
+	}{
+		{logic.Cover{Variables: 2, Form: logic.DNF}, 0, 0},
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 1}}}, 0, 0},
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 3, Values: 1}}}, 1, 2},
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 1}, {Mask: 2}}}, 1, 2},
+		{logic.Cover{Variables: 3, Form: logic.ESOP, Cubes: []logic.Cube{{Mask: 3}, {Mask: 7}, {Mask: 4}}}, 3, 8},
+	}
+	for _, test := range tests {
+		gates, inputs := TwoLevelGates(test.cover)
+		if gates != test.gates || inputs != test.inputs {
+			t.Errorf("%s: %d gates with %d inputs, want %d with %d", test.cover.PrettyString(), gates, inputs, test.gates, test.inputs)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Пакет decompose - функциональная декомпозиция ФАЛ по Ашенхерсту-Кертису
+//
+// Функция f(X) раскладывается в f = F(h1(B), ..., hk(B), A), где
+// B - связанные переменные, A - свободные. Карта декомпозиции - таблица
+// значений f, строки которой - наборы A, а столбцы - наборы B; число
+// различных столбцов (кратность) μ определяет число функций h:
+// k = ceil(log2 μ). При μ <= 2 декомпозиция простая (Ашенхерст)
+package decompose
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Разбиение переменных функции: Bound - связанные переменные, которые
+// заменяются функциями h, Free - свободные; номера по возрастанию
+type Partition struct {
+	Bound []int
+	Free  []int
+}
+
+// Функция записывает разбиение: "{x0, x2 | x1, x3}" - связанные, затем свободные
+func (p Partition) String() string {
+	names := func(variables []int) string {
+		formatted := make([]string, len(variables))
+		for i, v := range variables {
+			formatted[i] = "x" + strconv.Itoa(v)
+		}
+		return strings.Join(formatted, ", ")
+	}
+	return "{" + names(p.Bound) + " | " + names(p.Free) + "}"
+}
+
+// Функция строит разбиение по маске связанных переменных: бит i - x_i
+func NewPartition(n int, bound uint32) Partition {
+	var p Partition
+	for i := 0; i < n; i++ {
+		if bound&(1<<uint(i)) != 0 {
+			p.Bound = append(p.Bound, i)
+		} else {
+			p.Free = append(p.Free, i)
+		}
+	}
+	return p
+}
+
+// Функция возвращает все разбиения n переменных с непустыми множествами
+// связанных и свободных переменных, по возрастанию числа связанных
+func Partitions(n int) []Partition {
+	var partitions []Partition
+	for size := 1; size < n; size++ {
+		for bound := uint32(1); bound < 1<<uint(n)-1; bound++ {
+			if bits.OnesCount32(bound) == size {
+				partitions = append(partitions, NewPartition(n, bound))
+			}
+		}
+	}
+	return partitions
+}
+
+// Функция возвращает номер набора функции от n переменных, в котором
+// связанные переменные принимают значения column, а свободные - row;
+// первая переменная каждого множества - старший разряд
+func (p Partition) point(n, row, column int) int {
+	point := 0
+	for j, v := range p.Bound {
+		if column&(1<<uint(len(p.Bound)-1-j)) != 0 {
+			point |= 1 << uint(n-1-v)
+		}
+	}
+	for j, v := range p.Free {
+		if row&(1<<uint(len(p.Free)-1-j)) != 0 {
+			point |= 1 << uint(n-1-v)
+		}
+	}
+	return point
+}
+
+// Карта декомпозиции
+type Chart struct {
+	Partition
+	// Values[row][column] - значение функции на наборе свободных
+	// переменных row и связанных column
+	Values [][]int
+	// Classes[column] - номер класса совместимых столбцов
+	Classes []int
+	// Patterns[class][row] - столбец класса; DontCare, если значение
+	// не определено во всех столбцах класса
+	Patterns [][]int
+}
+
+// Функция проверяет, совпадает ли столбец с классом на определенных наборах
+func compatible(pattern []int, values [][]int, column int) bool {
+	for row, value := range pattern {
+		v := values[row][column]
+		if value != logic.DontCare && v != logic.DontCare && value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция строит карту декомпозиции функции spec для разбиения p
+// Столбцы объединяются в классы жадно, в порядке номеров: если функция
+// не определена на части наборов, кратность может быть больше минимальной
+func NewChart(spec logic.Spec, p Partition) (Chart, error) {
+	if err := spec.Validate(); err != nil {
+		return Chart{}, err
+	}
+	seen := make([]bool, spec.Variables)
+	for _, v := range append(append([]int(nil), p.Bound...), p.Free...) {
+		if v < 0 || v >= spec.Variables || seen[v] {
+			return Chart{}, fmt.Errorf("invalid partition %s of %d variables", p, spec.Variables)
+		}
+		seen[v] = true
+	}
+	if len(p.Bound)+len(p.Free) != spec.Variables {
+		return Chart{}, fmt.Errorf("invalid partition %s of %d variables", p, spec.Variables)
+	}
+
+	c := Chart{Partition: p}
+	rows, columns := 1<<uint(len(p.Free)), 1<<uint(len(p.Bound))
+	c.Values = make([][]int, rows)
+	for row := range c.Values {
+		c.Values[row] = make([]int, columns)
+		for column := range c.Values[row] {
+			c.Values[row][column] = spec.Values[p.point(spec.Variables, row, column)]
+		}
+	}
+	c.Classes = make([]int, columns)
+	for column := range c.Classes {
+		class := 0
+		for class < len(c.Patterns) && !compatible(c.Patterns[class], c.Values, column) {
+			class++
+		}
+		if class == len(c.Patterns) {
+			pattern := make([]int, rows)
+			for row := range pattern {
+				pattern[row] = logic.DontCare
+			}
+			c.Patterns = append(c.Patterns, pattern)
+		}
+		for row, pattern := range c.Patterns[class] {
+			if pattern == logic.DontCare {
+				c.Patterns[class][row] = c.Values[row][column]
+			}
+		}
+		c.Classes[column] = class
+	}
+	return c, nil
+}
+
+// Функция возвращает кратность столбцов - число классов
+func (c Chart) Multiplicity() int {
+	return len(c.Patterns)
+}
+
+// Функция возвращает число функций h, кодирующих номер класса
+func (c Chart) Codes() int {
+	return bits.Len(uint(c.Multiplicity() - 1))
+}
+
+// Функция сообщает, что декомпозиция простая: одна функция h
+func (c Chart) Simple() bool {
+	return c.Multiplicity() <= 2
+}
+
+// Функция сообщает, что декомпозиция уменьшает число входов функции:
+// функций h меньше, чем связанных переменных
+func (c Chart) Useful() bool {
+	return c.Codes() < len(c.Bound)
+}
+
+// Функция возвращает функции h1..hk от связанных переменных: hj - разряд
+// номера класса столбца, h1 - старший
+func (c Chart) Functions() []logic.Spec {
+	k := c.Codes()
+	functions := make([]logic.Spec, k)
+	for j := range functions {
+		values := make([]int, len(c.Classes))
+		for column, class := range c.Classes {
+			values[column] = class >> uint(k-1-j) & 1
+		}
+		functions[j] = logic.NewSpec(values)
+	}
+	return functions
+}
+
+// Функция возвращает функцию F(h1, ..., hk, A): на коде класса она равна
+// столбцу класса, а на кодах, которых нет среди классов, не определена
+func (c Chart) Composition() logic.Spec {
+	rows := len(c.Values)
+	values := make([]int, (1<<uint(c.Codes()))*rows)
+	for code := 0; code < 1<<uint(c.Codes()); code++ {
+		for row := 0; row < rows; row++ {
+			value := logic.DontCare
+			if code < len(c.Patterns) {
+				value = c.Patterns[code][row]
+			}
+			values[code*rows+row] = value
+		}
+	}
+	return logic.NewSpec(values)
+}
+
+// Функция печатает карту: строки - наборы свободных переменных, столбцы -
+// наборы связанных, в последней строке - классы столбцов
+func (c Chart) PrettyString() string {
+	names := func(variables []int) string {
+		var formatted string
+		for _, v := range variables {
+			formatted += "x" + strconv.Itoa(v)
+		}
+		return formatted
+	}
+	value := func(v int) string {
+		if v == logic.DontCare {
+			return "-"
+		}
+		return strconv.Itoa(v)
+	}
+	label := len(names(c.Free))
+	if len("class") > label {
+		label = len("class")
+	}
+	cell := len(c.Bound)
+	if width := len(strconv.Itoa(c.Multiplicity() - 1)); width > cell {
+		cell = width
+	}
+	var formatted strings.Builder
+	fmt.Fprintf(&formatted, "%*s |", label, names(c.Bound))
+	for column := range c.Classes {
+		fmt.Fprintf(&formatted, " %*s", cell, fmt.Sprintf("%0*b", len(c.Bound), column))
+	}
+	fmt.Fprintf(&formatted, "\n%-*s |\n", label, names(c.Free))
+	for row, values := range c.Values {
+		fmt.Fprintf(&formatted, "%*s |", label, fmt.Sprintf("%0*b", len(c.Free), row))
+		for _, v := range values {
+			fmt.Fprintf(&formatted, " %*s", cell, value(v))
+		}
+		formatted.WriteString("\n")
+	}
+	fmt.Fprintf(&formatted, "%*s |", label, "class")
+	for _, class := range c.Classes {
+		fmt.Fprintf(&formatted, " %*d", cell, class)
+	}
+	fmt.Fprintf(&formatted, "\nmultiplicity %d, %d functions h\n", c.Multiplicity(), c.Codes())
+	return formatted.String()
+}
+
+// Функция строит карты декомпозиции для всех разбиений переменных spec
+func Charts(spec logic.Spec) ([]Chart, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	var charts []Chart
+	for _, p := range Partitions(spec.Variables) {
+		chart, err := NewChart(spec, p)
+		if err != nil {
+			return nil, err
+		}
+		charts = append(charts, chart)
+	}
+	return charts, nil
+}
+
+// Функция возвращает простые разделительные декомпозиции f = F(h(B), A)
+// с кратностью не больше 2 и хотя бы двумя связанными переменными
+func SimpleDecompositions(spec logic.Spec) ([]Chart, error) {
+	charts, err := Charts(spec)
+	if err != nil {
+		return nil, err
+	}
+	var simple []Chart
+	for _, chart := range charts {
+		if len(chart.Bound) >= 2 && chart.Simple() {
+			simple = append(simple, chart)
+		}
+	}
+	return simple, nil
+}
@@ -0,0 +1,142 @@
+package decompose
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"testing"
+)
+
+func TestPartitions(t *testing.T) {
+	tests := []struct {
+		n     int
+		count int
+		first string
+		last  string
+	}{
+		{2, 2, "{x0 | x1}", "{x1 | x0}"},
+		{3, 6, "{x0 | x1, x2}", "{x1, x2 | x0}"},
+		{4, 14, "{x0 | x1, x2, x3}", "{x1, x2, x3 | x0}"},
+	}
+	for _, test := range tests {
+		partitions := Partitions(test.n)
+		if len(partitions) != test.count || partitions[0].String() != test.first || partitions[len(partitions)-1].String() != test.last {
+			t.Errorf("%d variables: %d partitions %v", test.n, len(partitions), partitions)
+		}
+	}
+	if len(Partitions(1)) != 0 {
+		t.Error("partitions of a single variable")
+	}
+}
+
+// Композиция F(h1(B), ..., hk(B), A) совпадает с функцией на всех
+// определенных наборах для всех ФАЛ от 3 переменных и всех разбиений
+func TestChartComposition(t *testing.T) {
+	logictest.ForEachFunction(3, true, func(f []int) {
+		spec := logic.NewSpec(f)
+		charts, err := Charts(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, chart := range charts {
+			functions := chart.Functions()
+			composition := chart.Composition()
+			if len(functions) != chart.Codes() || composition.Variables != chart.Codes()+len(chart.Free) {
+				t.Fatalf("%v %s: %d functions h, F of %d variables", f, chart.Partition, len(functions), composition.Variables)
+			}
+			for row := range chart.Values {
+				for column := range chart.Classes {
+					code := 0
+					for _, h := range functions {
+						code = code<<1 | h.Values[column]
+					}
+					expected := f[chart.point(3, row, column)]
+					actual := composition.Values[code<<uint(len(chart.Free))|row]
+					if expected != logic.DontCare && actual != expected {
+						t.Errorf("%v %s: F = %d at row %d, column %d, want %d", f, chart.Partition, actual, row, column, expected)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestChart(t *testing.T) {
+	tests := []struct {
+		vector       string
+		bound        uint32
+		multiplicity int
+		simple       bool
+		useful       bool
+		classes      []int
+	}{
+		// x0 ^ x1 ^ x2: h = x0 ^ x1
+		{"01101001", 3, 2, true, true, []int{0, 1, 1, 0}},
+		// x0x1 + x2: h = x0x1
+		{"01010111", 3, 2, true, true, []int{0, 0, 0, 1}},
+		// !x0x1 + x0x2 (мультиплексор) по {x0, x1} не раскладывается
+		{"00110101", 3, 3, false, false, []int{0, 1, 2, 2}},
+		// Неопределенные наборы объединяют столбцы
+		{"0-1-", 1, 2, true, false, []int{0, 1}},
+		{"0--1", 1, 1, true, true, []int{0, 0}},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chart, err := NewChart(spec, NewPartition(spec.Variables, test.bound))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if chart.Multiplicity() != test.multiplicity || chart.Simple() != test.simple || chart.Useful() != test.useful ||
+			len(chart.Classes) != len(test.classes) {
+			t.Errorf("%s %s:\n%s", test.vector, chart.Partition, chart.PrettyString())
+			continue
+		}
+		for column, class := range test.classes {
+			if chart.Classes[column] != class {
+				t.Errorf("%s %s: classes %v, want %v", test.vector, chart.Partition, chart.Classes, test.classes)
+				break
+			}
+		}
+	}
+}
+
+func TestChartPrettyString(t *testing.T) {
+	spec, err := logic.ParseSpec("01101001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chart, err := NewChart(spec, NewPartition(3, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ` x0x1 | 00 01 10 11
+x2    |
+    0 |  0  1  1  0
+    1 |  1  0  0  1
+class |  0  1  1  0
+multiplicity 2, 1 functions h
+`
+	if chart.PrettyString() != want {
+		t.Errorf("chart\n%s\nwant\n%s", chart.PrettyString(), want)
+	}
+}
+
+func TestNewChartErrors(t *testing.T) {
+	spec := logic.NewSpec([]int{0, 1, 1, 0, 1, 0, 0, 1})
+	partitions := []Partition{
+		{Bound: []int{0}, Free: []int{1}},
+		{Bound: []int{0, 1}, Free: []int{1}},
+		{Bound: []int{0, 3}, Free: []int{1}},
+		{Bound: []int{0, 1}, Free: []int{-1}},
+	}
+	for _, p := range partitions {
+		if _, err := NewChart(spec, p); err == nil {
+			t.Errorf("NewChart accepted %s", p)
+		}
+	}
+	if _, err := NewChart(logic.NewSpec([]int{0, 1, 1}), Partition{Bound: []int{0}, Free: []int{1}}); err == nil {
+		t.Error("NewChart accepted a vector of length 3")
+	}
+}
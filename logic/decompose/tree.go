@@ -0,0 +1,327 @@
+package decompose
+
+import (
+	"context"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"strconv"
+	"strings"
+)
+
+// Узел дерева декомпозиции: переменная исходной функции либо функция Spec
+// от входов Inputs, где переменная x_i спецификации - вход Inputs[i]
+type Node struct {
+	Name string
+	// Номер переменной исходной функции; -1 у узла-функции
+	Variable int
+	// Вентиль узла бидекомпозиции; у остальных узлов - None
+	Operation Operation
+	Inputs    []*Node
+	Spec      logic.Spec
+	// Минимальное покрытие Spec, если узел минимизирован (Minimize)
+	Cover *logic.Cover
+}
+
+// Функция возвращает узел переменной x_i
+func Variable(i int) *Node {
+	return &Node{Name: "x" + strconv.Itoa(i), Variable: i}
+}
+
+// Функция сообщает, что узел - переменная исходной функции
+func (n *Node) IsVariable() bool {
+	return n.Variable >= 0
+}
+
+// Функция возвращает узлы-функции поддерева: входы раньше узлов,
+// которые их используют, корень последним
+func (n *Node) Nodes() []*Node {
+	if n.IsVariable() {
+		return nil
+	}
+	var nodes []*Node
+	for _, input := range n.Inputs {
+		nodes = append(nodes, input.Nodes()...)
+	}
+	return append(nodes, n)
+}
+
+// Функция вычисляет значение поддерева на наборе point функции от
+// variables переменных: через покрытие узла, если оно есть, иначе через Spec
+// Если значение какого-либо входа не определено, не определено и значение
+func (n *Node) Value(point, variables int) int {
+	if n.IsVariable() {
+		return point >> uint(variables-1-n.Variable) & 1
+	}
+	local := 0
+	for _, input := range n.Inputs {
+		value := input.Value(point, variables)
+		if value == logic.DontCare {
+			return logic.DontCare
+		}
+		local = local<<1 | value
+	}
+	if n.Cover == nil {
+		return n.Spec.Values[local]
+	}
+	return coverValue(*n.Cover, local, len(n.Inputs))
+}
+
+// Функция вычисляет значение покрытия на наборе point функции от n переменных
+func coverValue(cover logic.Cover, point, n int) int {
+	hits := 0
+	for _, cube := range cover.Cubes {
+		if cube.Contains(point, n) {
+			hits++
+		}
+	}
+	switch cover.Form {
+	case logic.CNF:
+		// Дизъюнкт обращается в ноль на наборах своего куба
+		if hits != 0 {
+			return 0
+		}
+		return 1
+	case logic.ESOP:
+		return hits % 2
+	}
+	if hits != 0 {
+		return 1
+	}
+	return 0
+}
+
+// Функция проверяет дерево на всех наборах spec
+// На неопределенных наборах допустимо любое значение
+func (n *Node) Verify(spec logic.Spec) ([]logic.Mismatch, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	var mismatches []logic.Mismatch
+	for point, expected := range spec.Values {
+		if expected == logic.DontCare {
+			continue
+		}
+		if actual := n.Value(point, spec.Variables); actual != expected {
+			mismatches = append(mismatches, logic.Mismatch{
+				Point:    point,
+				Size:     spec.Variables,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+// Функция минимизирует функции всех узлов, кроме вентилей, алгоритмом backend
+func (n *Node) Minimize(ctx context.Context, backend string, options logic.Options) error {
+	for _, node := range n.Nodes() {
+		if node.Operation != None {
+			continue
+		}
+		result, err := logic.Minimize(ctx, backend, node.Spec, options)
+		if err != nil {
+			return err
+		}
+		node.Cover = &result.Cover
+	}
+	return nil
+}
+
+// Функция записывает покрытие узла через имена его входов: "g1!x3 + x2";
+// если узел не минимизирован - вектор значений
+func (n *Node) Expression() string {
+	if n.IsVariable() {
+		return n.Name
+	}
+	if n.Operation != None {
+		return n.Operation.join(n.Inputs[0].Name, n.Inputs[1].Name)
+	}
+	if n.Cover == nil {
+		var vector string
+		for _, value := range n.Spec.Values {
+			if value == logic.DontCare {
+				vector += "-"
+			} else {
+				vector += strconv.Itoa(value)
+			}
+		}
+		return vector
+	}
+	cover := *n.Cover
+	separator := " + "
+	switch cover.Form {
+	case logic.CNF:
+		separator = ""
+	case logic.ESOP:
+		separator = " ^ "
+	}
+	var terms []string
+	for _, cube := range cover.Cubes {
+		var literals []string
+		for i, input := range n.Inputs {
+			bit := uint32(1) << uint(i)
+			if cube.Mask&bit == 0 {
+				continue
+			}
+			literal := input.Name
+			if cube.Complemented(cover.Form)&bit != 0 {
+				literal = "!" + literal
+			}
+			literals = append(literals, literal)
+		}
+		switch {
+		case cover.Form != logic.CNF:
+			if len(literals) == 0 {
+				terms = append(terms, "1")
+			} else {
+				terms = append(terms, strings.Join(literals, ""))
+			}
+		case len(literals) == 1:
+			terms = append(terms, literals[0])
+		default:
+			terms = append(terms, "("+strings.Join(literals, " + ")+")")
+		}
+	}
+	if len(terms) == 0 {
+		// Пустая ДНФ и ESOP равны 0, пустая КНФ - 1
+		if cover.Form == logic.CNF {
+			return "1"
+		}
+		return "0"
+	}
+	return strings.Join(terms, separator)
+}
+
+// Функция записывает узел: "g1(x0, x1) = x0!x1 + !x0x1"
+func (n *Node) String() string {
+	if n.IsVariable() {
+		return n.Name
+	}
+	names := make([]string, len(n.Inputs))
+	for i, input := range n.Inputs {
+		names[i] = input.Name
+	}
+	return fmt.Sprintf("%s(%s) = %s", n.Name, strings.Join(names, ", "), n.Expression())
+}
+
+// Функция печатает дерево: каждый узел-функция на своей строке,
+// входы - с отступом под узлом
+func (n *Node) PrettyString() string {
+	var formatted strings.Builder
+	var write func(node *Node, depth int)
+	write = func(node *Node, depth int) {
+		if node.IsVariable() {
+			return
+		}
+		formatted.WriteString(strings.Repeat("  ", depth) + node.String() + "\n")
+		for _, input := range node.Inputs {
+			write(input, depth+1)
+		}
+	}
+	write(n, 0)
+	return formatted.String()
+}
+
+// Функция возвращает наибольшее число входов узла поддерева
+func (n *Node) MaxInputs() int {
+	max := 0
+	for _, node := range n.Nodes() {
+		if len(node.Inputs) > max {
+			max = len(node.Inputs)
+		}
+	}
+	return max
+}
+
+// Состояние рекурсивной декомпозиции
+type decomposer struct {
+	maxInputs int
+	functions int
+}
+
+// Ключ выбора разбиения: связанных переменных больше maxInputs,
+// минус выигрыш во входах, кратность; меньший ключ лучше
+type key [3]int
+
+func (a key) less(b key) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func (d *decomposer) key(c Chart) key {
+	oversized := 0
+	if d.maxInputs > 0 && len(c.Bound) > d.maxInputs {
+		oversized = 1
+	}
+	return key{oversized, c.Codes() - len(c.Bound), c.Multiplicity()}
+}
+
+// Функция выбирает разбиение, уменьшающее число входов функции: сначала
+// с функциями h не больше чем от maxInputs переменных, затем с наибольшим
+// выигрышем во входах и наименьшей кратностью
+func (d *decomposer) best(spec logic.Spec) (Chart, bool) {
+	var best Chart
+	found := false
+	for _, p := range Partitions(spec.Variables) {
+		chart, err := NewChart(spec, p)
+		if err != nil || !chart.Useful() {
+			continue
+		}
+		if !found || d.key(chart).less(d.key(best)) {
+			best, found = chart, true
+		}
+	}
+	return best, found
+}
+
+// Функция раскладывает узел, пока у него больше maxInputs входов
+// и есть разбиение, уменьшающее их число
+func (d *decomposer) decompose(node *Node) {
+	if len(node.Inputs) <= d.maxInputs {
+		return
+	}
+	chart, found := d.best(node.Spec)
+	if !found {
+		return
+	}
+	bound := make([]*Node, len(chart.Bound))
+	for i, v := range chart.Bound {
+		bound[i] = node.Inputs[v]
+	}
+	var inputs []*Node
+	for _, spec := range chart.Functions() {
+		d.functions++
+		h := &Node{Name: "g" + strconv.Itoa(d.functions), Variable: -1, Inputs: bound, Spec: spec}
+		d.decompose(h)
+		inputs = append(inputs, h)
+	}
+	for _, v := range chart.Free {
+		inputs = append(inputs, node.Inputs[v])
+	}
+	node.Inputs = inputs
+	node.Spec = chart.Composition()
+	// Число входов уменьшилось, поэтому рекурсия конечна
+	d.decompose(node)
+}
+
+// Функция рекурсивно раскладывает функцию spec в дерево функций не больше
+// чем от maxInputs переменных (0 - раскладывать, пока возможно)
+// Корень дерева называется f, функции h - g1, g2, ...; узел, который
+// нельзя разложить с уменьшением числа входов, остается больше maxInputs
+func Decompose(spec logic.Spec, maxInputs int) (*Node, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	root := &Node{Name: "f", Variable: -1, Spec: spec}
+	for i := 0; i < spec.Variables; i++ {
+		root.Inputs = append(root.Inputs, Variable(i))
+	}
+	d := &decomposer{maxInputs: maxInputs}
+	d.decompose(root)
+	return root, nil
+}
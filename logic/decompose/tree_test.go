@@ -0,0 +1,87 @@
+package decompose
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"math/rand"
+	"testing"
+)
+
+// Дерево декомпозиции совпадает с функцией на всех ФАЛ от 3 переменных
+// до и после минимизации узлов
+func TestDecomposeExhaustive(t *testing.T) {
+	logictest.ForEachFunction(3, true, func(f []int) {
+		spec := logic.NewSpec(f)
+		for _, maxInputs := range []int{0, 1, 2} {
+			root, err := Decompose(spec, maxInputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mismatches, _ := root.Verify(spec); len(mismatches) != 0 {
+				t.Fatalf("%v, %d inputs:\n%s%v", f, maxInputs, root.PrettyString(), mismatches)
+			}
+			if err := root.Minimize(context.Background(), "qmc", logic.Options{}); err != nil {
+				t.Fatal(err)
+			}
+			if mismatches, _ := root.Verify(spec); len(mismatches) != 0 {
+				t.Fatalf("%v, %d inputs, minimized:\n%s%v", f, maxInputs, root.PrettyString(), mismatches)
+			}
+		}
+	})
+}
+
+// Случайные ФАЛ от 4-6 переменных с неопределенными наборами
+func TestDecomposeRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 4 + random.Intn(3)
+		f := make([]int, 1<<uint(n))
+		for point := range f {
+			f[point] = random.Intn(3)
+			if f[point] == 2 {
+				f[point] = logic.DontCare
+			}
+		}
+		spec := logic.NewSpec(f)
+		root, err := Decompose(spec, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mismatches, _ := root.Verify(spec); len(mismatches) != 0 {
+			t.Fatalf("%v:\n%s%v", f, root.PrettyString(), mismatches)
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	tests := []struct {
+		vector    string
+		maxInputs int
+		tree      string
+	}{
+		{"01101001", 2, "f(g1, x2) = !g1x2 + g1!x2\n  g1(x0, x1) = !x0x1 + x0!x1\n"},
+		{"0110100110010110", 2,
+			"f(g2, x3) = !g2x3 + g2!x3\n  g2(g1, x2) = !g1x2 + g1!x2\n    g1(x0, x1) = !x0x1 + x0!x1\n"},
+		// Мультиплексор не раскладывается и остается от 3 переменных
+		{"00110101", 2, "f(x0, x1, x2) = !x0x1 + x0x2\n"},
+		{"0001000100011111", 0, "f(g2, g1) = g1 + g2\n  g2(x2, x3) = x2x3\n  g1(x0, x1) = x0x1\n"},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := Decompose(spec, test.maxInputs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := root.Minimize(context.Background(), "qmc", logic.Options{}); err != nil {
+			t.Fatal(err)
+		}
+		if root.PrettyString() != test.tree {
+			t.Errorf("%s:\n%swant\n%s", test.vector, root.PrettyString(), test.tree)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Пакет logic - библиотека минимизации функций алгебры логики (ФАЛ)
+//
+// Пакет описывает общие для всех алгоритмов понятия: спецификацию ФАЛ
+// (таблицу истинности) Spec, куб Cube, покрытие Cover в ДНФ, КНФ или ESOP,
+// проверку покрытия VerifyDNF/VerifyCNF/VerifyESOP и интерфейс Minimizer.
+// Сами алгоритмы находятся во вложенных пакетах и регистрируются при импорте:
+//
+//	qmc  - метод Квайна-Мак-Класки, таблица покрытия qmc.Table
+//	nk   - метод неопределенных коэффициентов
+//	esop - сумма по модулю 2 конъюнкций: точно до esop.ExactVariables
+//	       переменных, для остальных - преобразования EXORLINK
+//
+// Пакет sat - CDCL SAT-решатель с чтением и записью DIMACS CNF; через него
+// работает алгоритм qmc-sat и проверка эквивалентности покрытий
+// sat.Equivalent без перебора наборов
+//
+// Пакет decompose раскладывает ФАЛ по Ашенхерсту-Кертису в дерево функций
+// от меньшего числа переменных, каждую из которых можно минимизировать
+// любым алгоритмом, а decompose.Bidecompose - в многоуровневую схему
+// f = g(X1) op h(X2) из вентилей И, ИЛИ и XOR. Пакет factor строит
+// из ДНФ многоуровневую сеть: ядра, вынесение общих подвыражений и
+// скобочные формы вида x1(x2 + !x3) + ...
+//
+// Пакет techmap отображает ДНФ на схему И-НЕ/И-НЕ, а КНФ - на схему
+// ИЛИ-НЕ/ИЛИ-НЕ с заданным числом входов вентилей и считает микросхемы
+// серии 74 (7400, 7410, 7420, 7402, ...)
+//
+// Минимизация ФАЛ, заданной вектором значений ('-' - неопределенный набор):
+//
+//	import (
+//		"github.com/AndreevSemen/asvt/logic"
+//		_ "github.com/AndreevSemen/asvt/logic/qmc"
+//	)
+//
+//	spec, err := logic.ParseSpec("0110100-11-01110")
+//	if err != nil {
+//		return err
+//	}
+//	result, err := logic.Minimize(context.Background(), "qmc", spec, logic.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(result.Cover.PrettyString(), result.Cost) // стоимость в литералах
+//	// x3!x2!x1 + !x3x2!x1 + !x3!x2x1 + !x2x0 + !x3x0 13
+//
+// Проверка покрытия, полученного любым способом:
+//
+//	mismatches, err := result.Cover.Verify(spec.Values)
+//	if err != nil {
+//		return err
+//	}
+//	for _, mismatch := range mismatches {
+//		fmt.Println(mismatch) // false positive at 13 (1101): by ...
+//	}
+//
+// Отдельные шаги методов доступны из их пакетов, например таблица покрытия
+// после поиска существенных импликант с учетом неопределенных наборов:
+//
+//	steps, err := qmc.Prepare(spec)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(steps.Table.PrettyString(), len(steps.Essential))
+//
+// Исполняемые примеры находятся в example_test.go
+//
+// Функции пакета возвращают ошибки на некорректных входных данных;
+// паникует только Register при повторной регистрации алгоритма
+// Ошибки проверки типизированы и различаются через errors.As:
+//
+//	_, err := logic.ParseSpec("0110100")
+//	var lengthErr *logic.LengthError
+//	if errors.As(err, &lengthErr) {
+//		fmt.Println(lengthErr.Length) // 7
+//	}
+//
+// LengthError - длина вектора не степень двойки, ValueError - недопустимое
+// значение на наборе, ArityError - несовпадение числа переменных
+package logic
+
+// Версия библиотеки по правилам семантического версионирования
+// Несовместимые изменения API увеличивают старший номер
+const Version = "0.1.0"
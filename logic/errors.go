@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Ошибка длины вектора значений: она должна быть степенью двойки
+// и не превышать 2^MaxVariables
+type LengthError struct {
+	Length int
+}
+
+func (e *LengthError) Error() string {
+	if uint64(e.Length) > uint64(1)<<MaxVariables {
+		return fmt.Sprintf("truth vector of %d values has more than %d variables", e.Length, MaxVariables)
+	}
+	return fmt.Sprintf("truth vector length %d is not a power of two", e.Length)
+}
+
+// Ошибка значения ФАЛ на наборе Point: допустимы 0, 1 и DontCare,
+// а в записи вектора - символы '0', '1' и '-'
+type ValueError struct {
+	Point int
+	Value string
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("bad value %s at point %d", e.Value, e.Point)
+}
+
+// Ошибка несовпадения числа переменных: у импликант, у покрытия и вектора
+// значений, у спецификации и ее вектора
+type ArityError struct {
+	Expected int
+	Actual   int
+}
+
+func (e *ArityError) Error() string {
+	return fmt.Sprintf("arity mismatch: expected %d variables, got %d", e.Expected, e.Actual)
+}
+
+// Функция проверяет вектор значений ФАЛ и возвращает число ее переменных
+func Arity(f []int) (int, error) {
+	length := uint64(len(f))
+	if length == 0 || length&(length-1) != 0 || length > 1<<MaxVariables {
+		return 0, &LengthError{Length: len(f)}
+	}
+	for point, value := range f {
+		if value != 0 && value != 1 && value != DontCare {
+			return 0, &ValueError{Point: point, Value: fmt.Sprint(value)}
+		}
+	}
+	return bits.TrailingZeros64(length), nil
+}
@@ -0,0 +1,80 @@
+package logic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArity(t *testing.T) {
+	tests := []struct {
+		f         []int
+		variables int
+		err       error
+	}{
+		{[]int{1}, 0, nil},
+		{[]int{0, 1}, 1, nil},
+		{[]int{0, 1, DontCare, 1}, 2, nil},
+		{nil, 0, &LengthError{Length: 0}},
+		{[]int{0, 1, 1}, 0, &LengthError{Length: 3}},
+		{[]int{0, 2}, 0, &ValueError{Point: 1, Value: "2"}},
+		{[]int{0, 1, -2, 1}, 0, &ValueError{Point: 2, Value: "-2"}},
+	}
+	for _, test := range tests {
+		variables, err := Arity(test.f)
+		if variables != test.variables {
+			t.Errorf("Arity(%v) = %d, want %d", test.f, variables, test.variables)
+		}
+		if test.err == nil && err != nil || test.err != nil && (err == nil || err.Error() != test.err.Error()) {
+			t.Errorf("Arity(%v): error %v, want %v", test.f, err, test.err)
+		}
+	}
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	tests := []struct {
+		vector string
+		target interface{}
+		err    string
+	}{
+		{"0110100", new(*LengthError), "truth vector length 7 is not a power of two"},
+		{"", new(*LengthError), "truth vector length 0 is not a power of two"},
+		{"01x0", new(*ValueError), "bad value 'x' at point 2"},
+		{"01 0", new(*ValueError), "bad value ' ' at point 2"},
+	}
+	for _, test := range tests {
+		_, err := ParseSpec(test.vector)
+		if err == nil {
+			t.Errorf("ParseSpec(%q) succeeded", test.vector)
+			continue
+		}
+		if !errors.As(err, test.target) {
+			t.Errorf("ParseSpec(%q): error %T, want %T", test.vector, err, test.target)
+		}
+		if err.Error() != test.err {
+			t.Errorf("ParseSpec(%q): %q, want %q", test.vector, err, test.err)
+		}
+	}
+}
+
+func TestLengthErrorTooLong(t *testing.T) {
+	// На 32-битных платформах такой вектор не построить
+	shift := uint(MaxVariables + 1)
+	if uint64(^uint(0)) < uint64(1)<<shift {
+		t.Skip("int is too narrow")
+	}
+	err := &LengthError{Length: int(uint64(1) << shift)}
+	if want := "truth vector of 8589934592 values has more than 32 variables"; err.Error() != want {
+		t.Errorf("message %q, want %q", err, want)
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	spec := Spec{Variables: 3, Values: []int{0, 1, 1, 0}}
+	var arityErr *ArityError
+	if err := spec.Validate(); !errors.As(err, &arityErr) || arityErr.Expected != 2 || arityErr.Actual != 3 {
+		t.Errorf("Validate: %v, want arity mismatch 2 vs 3", err)
+	}
+	if err := NewSpec([]int{0, 1, 1, 0}).Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
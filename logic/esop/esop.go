@@ -0,0 +1,200 @@
+// Пакет esop - минимизация ФАЛ в виде суммы по модулю 2 конъюнкций (ESOP)
+//
+// Кубы представлены так же, как импликанты метода Квайна-Мак-Класки
+// (qmc.Term). Для функций до ExactVariables переменных минимум ищется
+// точно, для остальных - преобразованиями пар кубов EXORLINK,
+// как в EXORCISM
+package esop
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+)
+
+// Функция возвращает третье значение переменной: сумма по модулю 2
+// литералов a и b различных значений, например x ^ !x = 1, x ^ 1 = !x
+func third(a, b qmc.Bit) qmc.Bit {
+	return -(a + b)
+}
+
+// Функция возвращает номера переменных, в которых кубы различаются
+func differences(a, b qmc.Term) []int {
+	var positions []int
+	for i := range a {
+		if a[i] != b[i] {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// Функция возвращает все способы заменить сумму кубов a ^ b, различающихся
+// в d переменных, суммой d кубов (EXORLINK-d)
+// Для порядка p1..pd различающихся переменных m-й куб берет значения b
+// в p1..p(m-1), третье значение в pm и значения a в остальных:
+// a1a2 ^ b1b2 = (a1^b1)a2 ^ b1(a2^b2)
+// При d = 0 кубы взаимно уничтожаются, при d = 1 склеиваются в один
+func Exorlink(a, b qmc.Term) [][]qmc.Term {
+	positions := differences(a, b)
+	if len(positions) == 0 {
+		return [][]qmc.Term{nil}
+	}
+	var links [][]qmc.Term
+	permute(positions, 0, func(order []int) {
+		cubes := make([]qmc.Term, len(order))
+		for m, position := range order {
+			cube := make(qmc.Term, len(a))
+			copy(cube, a)
+			for _, before := range order[:m] {
+				cube[before] = b[before]
+			}
+			cube[position] = third(a[position], b[position])
+			cubes[m] = cube
+		}
+		links = append(links, cubes)
+	})
+	return links
+}
+
+// Функция перебирает перестановки positions[k:], вызывая visit для каждой
+func permute(positions []int, k int, visit func([]int)) {
+	if k == len(positions) {
+		visit(positions)
+		return
+	}
+	for i := k; i < len(positions); i++ {
+		positions[k], positions[i] = positions[i], positions[k]
+		permute(positions, k+1, visit)
+		positions[k], positions[i] = positions[i], positions[k]
+	}
+}
+
+// Ключ сравнения сумм: стоимость, затем число кубов
+type key [2]int
+
+func (a key) less(b key) bool {
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
+
+// Состояние эвристической минимизации
+type heuristic struct {
+	cost    logic.CostModel
+	tracker *logic.Tracker
+}
+
+func (h *heuristic) key(cubes []qmc.Term) key {
+	return key{Cost(h.cost, cubes), len(cubes)}
+}
+
+// Функция склеивает пары кубов на расстоянии 0 и 1, пока это возможно:
+// одинаковые кубы взаимно уничтожаются, а соседние заменяются одним
+func merge(cubes []qmc.Term) []qmc.Term {
+	cubes = append([]qmc.Term(nil), cubes...)
+	for merged := true; merged; {
+		merged = false
+	search:
+		for i := range cubes {
+			for j := i + 1; j < len(cubes); j++ {
+				if len(differences(cubes[i], cubes[j])) > 1 {
+					continue
+				}
+				link := Exorlink(cubes[i], cubes[j])[0]
+				rest := append(append(cubes[:i:i], cubes[i+1:j]...), cubes[j+1:]...)
+				cubes = append(rest, link...)
+				merged = true
+				break search
+			}
+		}
+	}
+	return cubes
+}
+
+// Функция сообщает, есть ли у куба сосед на расстоянии не больше 1
+// среди cubes, то есть можно ли его склеить после замены
+func hasPartner(cube qmc.Term, cubes []qmc.Term) bool {
+	for _, other := range cubes {
+		if len(differences(cube, other)) <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Функция ищет пару кубов на расстоянии d, замена которой по EXORLINK
+// с последующей склейкой уменьшает ключ суммы
+// Замена d кубами добавляет d-2 куба, поэтому пробуются только замены,
+// в которых склеить можно хотя бы d-1 новых кубов, либо, при d = 2,
+// замены, которые уменьшают стоимость сами по себе
+func (h *heuristic) link(cubes []qmc.Term, d int) ([]qmc.Term, bool) {
+	current := h.key(cubes)
+	for i := range cubes {
+		for j := i + 1; j < len(cubes); j++ {
+			if !h.tracker.Step() {
+				return nil, false
+			}
+			if len(differences(cubes[i], cubes[j])) != d {
+				continue
+			}
+			rest := append(append(cubes[:i:i], cubes[i+1:j]...), cubes[j+1:]...)
+			for _, link := range Exorlink(cubes[i], cubes[j]) {
+				partners := 0
+				for _, cube := range link {
+					if hasPartner(cube, rest) {
+						partners++
+					}
+				}
+				candidate := append(rest[:len(rest):len(rest)], link...)
+				if partners < d-1 && !(d == 2 && h.key(candidate).less(current)) {
+					continue
+				}
+				if candidate = merge(candidate); h.key(candidate).less(current) {
+					return candidate, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Функция минимизирует сумму кубов преобразованиями EXORLINK-2 и
+// EXORLINK-3: каждое принятое преобразование уменьшает стоимость в модели
+// cost либо, при равной стоимости, число кубов, поэтому поиск конечен
+// Результат - локальный минимум; если tracker останавливает поиск,
+// возвращается лучшая найденная сумма
+func Heuristic(cubes []qmc.Term, cost logic.CostModel, tracker *logic.Tracker) []qmc.Term {
+	tracker.SetStage("esop exorlink")
+	h := &heuristic{cost: cost, tracker: tracker}
+	cubes = merge(cubes)
+	tracker.Found(Cost(cost, cubes))
+	for {
+		next, improved := h.link(cubes, 2)
+		if !improved {
+			next, improved = h.link(cubes, 3)
+		}
+		if !improved {
+			return cubes
+		}
+		cubes = next
+		tracker.Found(Cost(cost, cubes))
+	}
+}
+
+// Функция возвращает стоимость суммы кубов в модели cost
+func Cost(cost logic.CostModel, cubes []qmc.Term) int {
+	return MakeCover(0, cubes).Cost(cost)
+}
+
+// Функция преобразует кубы в покрытие-сумму по модулю 2
+func MakeCover(variables int, cubes []qmc.Term) logic.Cover {
+	cover := qmc.MakeCover(variables, cubes)
+	cover.Form = logic.ESOP
+	return cover
+}
+
+// Функция форматирует сумму кубов: "x1!x0 ^ x2"
+func Format(cubes []qmc.Term) string {
+	return MakeCover(0, cubes).PrettyString()
+}
@@ -0,0 +1,140 @@
+package esop
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"strings"
+	"testing"
+)
+
+// Функция возвращает таблицу истинности суммы кубов по модулю 2
+func sumTable(cubes []qmc.Term, n int) uint32 {
+	var table uint32
+	for _, cube := range cubes {
+		table ^= truthTable(cube, n)
+	}
+	return table
+}
+
+func TestExorlink(t *testing.T) {
+	x0x1 := qmc.Term{qmc.True, qmc.True}
+	tests := []struct {
+		a, b  qmc.Term
+		links []string
+	}{
+		{x0x1, x0x1, []string{"0"}},
+		{x0x1, qmc.Term{qmc.True, qmc.False}, []string{"x0"}},
+		{x0x1, qmc.Term{qmc.True, qmc.Tilde}, []string{"!x1x0"}},
+		{x0x1, qmc.Term{qmc.False, qmc.False}, []string{"x1 ^ !x0", "x0 ^ !x1"}},
+		{x0x1, qmc.Term{qmc.Tilde, qmc.False}, []string{"x1!x0 ^ 1", "x0 ^ !x1!x0"}},
+	}
+	for _, test := range tests {
+		links := Exorlink(test.a, test.b)
+		var got []string
+		for _, link := range links {
+			got = append(got, Format(link))
+		}
+		if strings.Join(got, ", ") != strings.Join(test.links, ", ") {
+			t.Errorf("%s ^ %s: %s, want %s", Format([]qmc.Term{test.a}), Format([]qmc.Term{test.b}),
+				strings.Join(got, ", "), strings.Join(test.links, ", "))
+		}
+	}
+}
+
+// Каждая замена EXORLINK-d сохраняет функцию и состоит из d кубов, а
+// всего замен d!
+func TestExorlinkEquivalent(t *testing.T) {
+	cubes := allCubes(3)
+	factorial := []int{1, 1, 2, 6}
+	for _, a := range cubes {
+		for _, b := range cubes {
+			d := len(differences(a, b))
+			links := Exorlink(a, b)
+			if len(links) != factorial[d] {
+				t.Fatalf("%s ^ %s: %d links", Format([]qmc.Term{a}), Format([]qmc.Term{b}), len(links))
+			}
+			for _, link := range links {
+				if len(link) != d || sumTable(link, 3) != sumTable([]qmc.Term{a, b}, 3) {
+					t.Errorf("%s ^ %s: link %s", Format([]qmc.Term{a}), Format([]qmc.Term{b}), Format(link))
+				}
+			}
+		}
+	}
+}
+
+// Точный поиск совпадает с перебором на ФАЛ до 2 переменных с
+// неопределенными наборами
+func TestExactBruteForce(t *testing.T) {
+	models := []logic.CostModel{logic.LiteralCost{}, logic.TermCost{}, logic.GateInputCost{}, logic.WeightedCost{Weights: []int{3, 1}}}
+	for n := 1; n <= 2; n++ {
+		logictest.ForEachFunction(n, true, func(f []int) {
+			for _, model := range models {
+				cubes, exact := Exact(logic.NewSpec(f), model, nil)
+				cover := MakeCover(n, cubes)
+				if mismatches, _ := cover.Verify(f); !exact || len(mismatches) != 0 {
+					t.Fatalf("%v: %s (exact %t): %v", f, cover.PrettyString(), exact, mismatches)
+				}
+				if want := logictest.BruteForceCost(f, n, logic.ESOP, model); cover.Cost(model) != want {
+					t.Errorf("%v (%s): %s costs %d, want %d", f, model.Name(), cover.PrettyString(), cover.Cost(model), want)
+				}
+			}
+		})
+	}
+}
+
+// На всех ФАЛ от 3 переменных обе суммы верны, а эвристика не лучше
+// точного поиска
+func TestHeuristic(t *testing.T) {
+	logictest.ForEachFunction(3, false, func(f []int) {
+		minterms, err := qmc.MakeSDNF(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		heuristic := MakeCover(3, Heuristic(minterms, logic.LiteralCost{}, nil))
+		exact, _ := Exact(logic.NewSpec(f), logic.LiteralCost{}, nil)
+		for _, cover := range []logic.Cover{heuristic, MakeCover(3, exact)} {
+			if mismatches, _ := cover.Verify(f); len(mismatches) != 0 {
+				t.Fatalf("%v: %s: %v", f, cover.PrettyString(), mismatches)
+			}
+		}
+		if heuristic.Cost(logic.LiteralCost{}) < Cost(logic.LiteralCost{}, exact) {
+			t.Errorf("%v: heuristic %s beats exact %s", f, heuristic.PrettyString(), Format(exact))
+		}
+	})
+}
+
+func TestMinimize(t *testing.T) {
+	tests := []struct {
+		vector  string
+		cost    int
+		optimal bool
+	}{
+		{"0110", 2, true},
+		{"01101001", 3, true},
+		{"0111", 2, true},
+		{"0000", 0, true},
+		{"1111", 0, true},
+		{"0-10", 2, true},
+		// Четность 5 переменных минимизируется эвристикой
+		{"01101001100101101001011001101001", 5, false},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := logic.Minimize(context.Background(), "esop", spec, logic.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mismatches, _ := result.Cover.Verify(spec.Values); len(mismatches) != 0 || result.Cover.Form != logic.ESOP {
+			t.Fatalf("%s: %s: %v", test.vector, result.Cover.PrettyString(), mismatches)
+		}
+		if result.Cost != test.cost || result.Optimal != test.optimal {
+			t.Errorf("%s: %s, cost %d (optimal %t), want %d (optimal %t)",
+				test.vector, result.Cover.PrettyString(), result.Cost, result.Optimal, test.cost, test.optimal)
+		}
+	}
+}
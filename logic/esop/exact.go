@@ -0,0 +1,125 @@
+package esop
+
+import (
+	"container/heap"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+)
+
+// Наибольшее число переменных, для которого ESOP ищется точно:
+// перебираются все 2^(2^n) таблиц истинности
+const ExactVariables = 4
+
+// Функция возвращает все 3^n кубов от n переменных
+func allCubes(n int) []qmc.Term {
+	cubes := []qmc.Term{{}}
+	for i := 0; i < n; i++ {
+		next := make([]qmc.Term, 0, 3*len(cubes))
+		for _, cube := range cubes {
+			for _, bit := range []qmc.Bit{qmc.Tilde, qmc.False, qmc.True} {
+				next = append(next, append(append(qmc.Term(nil), cube...), bit))
+			}
+		}
+		cubes = next
+	}
+	return cubes
+}
+
+// Функция возвращает таблицу истинности куба: бит p - значение на наборе p
+func truthTable(cube qmc.Term, n int) uint32 {
+	var table uint32
+	for point := 0; point < 1<<uint(n); point++ {
+		if cube.Contains(point, n) {
+			table |= 1 << uint(point)
+		}
+	}
+	return table
+}
+
+// Очередь таблиц истинности по возрастанию расстояния
+type queueItem struct {
+	distance int
+	table    uint32
+}
+
+type queue []queueItem
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].distance < q[j].distance }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(queueItem)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// Функция ищет ESOP минимальной стоимости для функции не больше чем
+// от ExactVariables переменных алгоритмом Дейкстры: вершины - таблицы
+// истинности, а ребро - прибавление куба по модулю 2
+// Вес ребра - вклад куба в стоимость cost, при равной стоимости
+// предпочитается меньшее число кубов; поиск заканчивается на первой
+// таблице, совпадающей с функцией на определенных наборах
+// Для неаддитивной модели минимизируется сумма вкладов кубов
+// Если tracker останавливает поиск, возвращается false
+func Exact(spec logic.Spec, cost logic.CostModel, tracker *logic.Tracker) ([]qmc.Term, bool) {
+	tracker.SetStage("esop exact")
+	n := spec.Variables
+	cubes := allCubes(n)
+	tables := make([]uint32, len(cubes))
+	weights := make([]int, len(cubes))
+	for i, cube := range cubes {
+		tables[i] = truthTable(cube, n)
+		// Минимальная сумма не повторяет кубы, поэтому в ней меньше
+		// len(cubes)+1 кубов и число кубов не перевешивает стоимость
+		weights[i] = cost.CubeCost(logic.ESOP, cube.Cube())*(len(cubes)+1) + 1
+	}
+	var want, care uint32
+	for point, value := range spec.Values {
+		if value != logic.DontCare {
+			care |= 1 << uint(point)
+		}
+		if value == 1 {
+			want |= 1 << uint(point)
+		}
+	}
+
+	states := 1 << uint(len(spec.Values))
+	distance := make([]int, states)
+	previous := make([]int, states)
+	for i := range distance {
+		distance[i] = -1
+	}
+	done := make([]bool, states)
+	distance[0] = 0
+	q := &queue{{0, 0}}
+	for q.Len() != 0 {
+		item := heap.Pop(q).(queueItem)
+		if done[item.table] {
+			continue
+		}
+		done[item.table] = true
+		if item.table&care == want {
+			var result []qmc.Term
+			for table := item.table; table != 0; table ^= tables[previous[table]] {
+				result = append(result, cubes[previous[table]])
+			}
+			return result, true
+		}
+		if !tracker.Step() {
+			return nil, false
+		}
+		for i, table := range tables {
+			next := item.table ^ table
+			d := item.distance + weights[i]
+			if done[next] || (distance[next] != -1 && distance[next] <= d) {
+				continue
+			}
+			distance[next] = d
+			previous[next] = i
+			heap.Push(q, queueItem{d, next})
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,74 @@
+package esop
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"time"
+)
+
+// Минимизация ESOP как алгоритм минимизации: результат - покрытие
+// в форме logic.ESOP
+type Minimizer struct{}
+
+func init() {
+	logic.Register(Minimizer{})
+}
+
+func (Minimizer) Name() string {
+	return "esop"
+}
+
+// Функции до ExactVariables переменных минимизируются точно, остальные -
+// преобразованиями EXORLINK, начиная с суммы единичных наборов
+// Эвристика доопределяет неопределенные наборы нулем
+func (Minimizer) Minimize(ctx context.Context, spec logic.Spec, options logic.Options) (logic.Result, error) {
+	if err := spec.Validate(); err != nil {
+		return logic.Result{}, err
+	}
+	start := time.Now()
+	cost := options.CostModel()
+	tracker := logic.NewTracker(ctx, options)
+
+	var cubes []qmc.Term
+	exact := false
+	candidates := 0
+	if spec.Variables <= ExactVariables {
+		cubes, exact = Exact(spec, cost, tracker)
+		candidates = len(allCubes(spec.Variables))
+	}
+	if !exact {
+		ones := make([]int, len(spec.Values))
+		for point, value := range spec.Values {
+			if value == 1 {
+				ones[point] = 1
+			}
+		}
+		minterms, err := qmc.MakeSDNF(ones)
+		if err != nil {
+			return logic.Result{}, err
+		}
+		cubes = Heuristic(minterms, cost, tracker)
+		candidates = len(minterms)
+	}
+
+	cover := MakeCover(spec.Variables, cubes)
+	total := cover.Cost(cost)
+	bound := 0
+	optimal := exact && cost.Additive()
+	if optimal {
+		bound = total
+	}
+	return logic.Result{
+		Cover:      cover,
+		Cost:       total,
+		LowerBound: bound,
+		Optimal:    optimal,
+		Stats: logic.Stats{
+			Duration:   time.Since(start),
+			Candidates: candidates,
+			Evaluated:  tracker.Evaluated(),
+			Stopped:    tracker.Err(),
+		},
+	}, logic.CanceledError(tracker.Err())
+}
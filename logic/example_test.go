@@ -0,0 +1,154 @@
+package logic_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+)
+
+func ExampleParseSpec() {
+	spec, err := logic.ParseSpec("0110100-")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(spec.Variables, spec.Points(1), spec.Points(logic.DontCare))
+	// Output: 3 [1 2 4] [7]
+}
+
+func ExampleParseSpec_error() {
+	_, err := logic.ParseSpec("0110100")
+	var lengthErr *logic.LengthError
+	if errors.As(err, &lengthErr) {
+		fmt.Println(lengthErr.Length)
+	}
+	_, err = logic.ParseSpec("01x0")
+	fmt.Println(err)
+	// Output:
+	// 7
+	// bad value 'x' at point 2
+}
+
+func ExampleMinimize() {
+	spec, err := logic.ParseSpec("0110100-11-01110")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	result, err := logic.Minimize(context.Background(), "qmc", spec, logic.Options{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Cover.PrettyString(), result.Cost)
+	fmt.Println(result.Certificate())
+	// Output:
+	// x3!x2!x1 + !x3x2!x1 + !x3!x2x1 + !x2x0 + !x3x0 13
+	// proven optimal, lower bound 13, gap 0
+}
+
+func ExampleMinimize_cnf() {
+	spec, err := logic.ParseSpec("01111110")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	result, err := logic.Minimize(context.Background(), "nk-cnf", spec, logic.Options{Cost: logic.TermCost{}})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s: %s, %d clauses\n", result.Cover.Form, result.Cover.PrettyString(), result.Cost)
+	// Output: cnf: (x2 + x1 + x0)(!x2 + !x1 + !x0), 2 clauses
+}
+
+func ExampleCover_Verify() {
+	cover := logic.Cover{
+		Variables: 2,
+		Form:      logic.DNF,
+		Cubes:     []logic.Cube{{Mask: 1, Values: 1}},
+	}
+	mismatches, err := cover.Verify([]int{0, 1, 1, 1})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, mismatch := range mismatches {
+		fmt.Println(mismatch)
+	}
+	// Output: false negative at 1 (01)
+}
+
+func ExampleCover_JSON() {
+	spec, err := logic.ParseSpec("0111")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	result, err := logic.Minimize(context.Background(), "qmc", spec, logic.Options{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	encoded, err := json.Marshal(result.Cover.JSON(logic.GateInputCost{}))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(encoded))
+	// Output: {"variables":2,"form":"dnf","cubes":["-1","1-"],"complexity":2,"cost_model":"gates","cost":4}
+}
+
+func ExampleParseCostModel() {
+	cover := logic.Cover{
+		Variables: 3,
+		Form:      logic.DNF,
+		Cubes:     []logic.Cube{{Mask: 3, Values: 1}, {Mask: 4, Values: 4}},
+	}
+	for _, name := range []string{"literals", "terms", "gates", "transistors", "weights=1,2,5"} {
+		model, err := logic.ParseCostModel(name)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(model.Name(), cover.Cost(model))
+	}
+	// Output:
+	// literals 3
+	// terms 2
+	// gates 5
+	// transistors 10
+	// weights 8
+}
+
+func ExampleNames() {
+	for _, name := range logic.Names() {
+		fmt.Println(name)
+	}
+	// Output:
+	// nk
+	// nk-cnf
+	// nk-greedy
+	// qmc
+	// qmc-sat
+}
+
+// Отдельные шаги метода Квайна-Мак-Класки доступны из пакета qmc
+func Example_steps() {
+	spec, err := logic.ParseSpec("0111")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	steps, err := qmc.Prepare(spec)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(qmc.Format(steps.Prime), len(steps.Essential))
+	// Output: x1 + x0 2
+}
@@ -0,0 +1,194 @@
+package factor
+
+import (
+	"strings"
+)
+
+// Вид узла скобочной формы
+type Kind int
+
+const (
+	// Константа: 0 - пустая сумма, 1 - пустое произведение
+	Constant Kind = iota
+	Leaf
+	Sum
+	Product
+)
+
+// Скобочная форма - дерево сумм и произведений литералов
+type Factored struct {
+	Kind     Kind
+	Literal  Literal
+	Value    int
+	Children []*Factored
+}
+
+// Функция возвращает форму куба: литерал либо произведение литералов
+func cubeForm(c Cube) *Factored {
+	if len(c) == 0 {
+		return &Factored{Kind: Constant, Value: 1}
+	}
+	if len(c) == 1 {
+		return &Factored{Kind: Leaf, Literal: c[0]}
+	}
+	product := &Factored{Kind: Product}
+	for _, l := range c {
+		product.Children = append(product.Children, &Factored{Kind: Leaf, Literal: l})
+	}
+	return product
+}
+
+// Функция возвращает сумму или произведение форм, раскрывая вложенные
+// одноименные операции и опуская нейтральные константы
+func combine(kind Kind, children ...*Factored) *Factored {
+	neutral := 0
+	if kind == Product {
+		neutral = 1
+	}
+	result := &Factored{Kind: kind}
+	for _, child := range children {
+		switch {
+		case child.Kind == Constant && child.Value == neutral:
+			continue
+		case child.Kind == kind:
+			result.Children = append(result.Children, child.Children...)
+		default:
+			result.Children = append(result.Children, child)
+		}
+	}
+	switch len(result.Children) {
+	case 0:
+		return &Factored{Kind: Constant, Value: neutral}
+	case 1:
+		return result.Children[0]
+	}
+	return result
+}
+
+// Функция выбирает делитель f для разложения: ядро, отличное от f, или
+// литерал, входящий хотя бы в два куба, при котором сумма литералов
+// частного, делителя и остатка меньше всего
+func divisor(f SOP) (SOP, bool) {
+	var candidates []SOP
+	for _, k := range Kernels(f) {
+		if k.Kernel.key() != f.key() {
+			candidates = append(candidates, k.Kernel)
+		}
+	}
+	counts := map[Literal]int{}
+	for _, c := range f {
+		for _, l := range c {
+			counts[l]++
+		}
+	}
+	for _, c := range f {
+		for _, l := range c {
+			if counts[l] > 1 {
+				candidates = append(candidates, SOP{{l}})
+				counts[l] = 0
+			}
+		}
+	}
+	var best SOP
+	bestLiterals := f.Literals()
+	for _, d := range candidates {
+		q, r := Divide(f, d)
+		if literals := q.Literals() + d.Literals() + r.Literals(); len(q) != 0 && literals < bestLiterals {
+			best, bestLiterals = d, literals
+		}
+	}
+	return best, best != nil
+}
+
+// Функция строит скобочную форму суммы: общий куб выносится за скобки,
+// затем сумма делится на лучший делитель f = q·d + r, и q, d, r
+// раскладываются рекурсивно
+func Factor(f SOP) *Factored {
+	if len(f) == 0 {
+		return &Factored{Kind: Constant, Value: 0}
+	}
+	if len(f) == 1 {
+		return cubeForm(f[0])
+	}
+	if common := f.CommonCube(); len(common) != 0 {
+		return combine(Product, cubeForm(common), Factor(f.DivideCube(common)))
+	}
+	d, found := divisor(f)
+	if !found {
+		var cubes []*Factored
+		for _, c := range f {
+			cubes = append(cubes, cubeForm(c))
+		}
+		return combine(Sum, cubes...)
+	}
+	q, r := Divide(f, d)
+	return combine(Sum, combine(Product, Factor(q), Factor(d)), Factor(r))
+}
+
+// Функция возвращает число литералов формы
+func (f *Factored) Literals() int {
+	if f.Kind == Leaf {
+		return 1
+	}
+	literals := 0
+	for _, child := range f.Children {
+		literals += child.Literals()
+	}
+	return literals
+}
+
+// Функция записывает форму для функции от n переменных:
+// "x1(x2 + !x3) + x4"
+func (f *Factored) Format(n int) string {
+	switch f.Kind {
+	case Constant:
+		if f.Value == 1 {
+			return "1"
+		}
+		return "0"
+	case Leaf:
+		return f.Literal.Format(n)
+	case Sum:
+		parts := make([]string, len(f.Children))
+		for i, child := range f.Children {
+			parts[i] = child.Format(n)
+		}
+		return strings.Join(parts, " + ")
+	}
+	var formatted string
+	for _, child := range f.Children {
+		if child.Kind == Sum {
+			formatted += "(" + child.Format(n) + ")"
+		} else {
+			formatted += child.Format(n)
+		}
+	}
+	return formatted
+}
+
+// Функция вычисляет форму на значениях переменных values
+func (f *Factored) Evaluate(values []int) int {
+	switch f.Kind {
+	case Constant:
+		return f.Value
+	case Leaf:
+		value := values[f.Literal.Variable()]
+		if f.Literal.Negated() {
+			return 1 - value
+		}
+		return value
+	case Sum:
+		for _, child := range f.Children {
+			if child.Evaluate(values) == 1 {
+				return 1
+			}
+		}
+		return 0
+	}
+	for _, child := range f.Children {
+		if child.Evaluate(values) == 0 {
+			return 0
+		}
+	}
+	return 1
+}
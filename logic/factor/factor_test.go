@@ -0,0 +1,58 @@
+package factor
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Функция проверяет, что скобочная форма равна сумме на всех наборах
+func equivalent(form *Factored, f SOP, n int) bool {
+	values := make([]int, n)
+	for point := 0; point < 1<<uint(n); point++ {
+		for v := range values {
+			values[v] = point >> uint(n-1-v) & 1
+		}
+		if form.Evaluate(values) != evaluate(f, values) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFactor(t *testing.T) {
+	tests := []struct {
+		f        string
+		factored string
+		literals int
+	}{
+		{"0", "0", 0},
+		{"1", "1", 0},
+		{"x0!x1", "x0!x1", 2},
+		{"x0x1 + x0x2", "x0(x1 + x2)", 3},
+		{"x0x2 + x0x3 + x1x2 + x1x3 + x4", "(x0 + x1)(x2 + x3) + x4", 5},
+		{"x0x1x2 + x0x1x3 + x4", "x0x1(x2 + x3) + x4", 5},
+		{"x0x1 + x2x3", "x0x1 + x2x3", 4},
+	}
+	for _, test := range tests {
+		f := parse(test.f)
+		form := Factor(f)
+		if form.Format(5) != test.factored || form.Literals() != test.literals {
+			t.Errorf("%s: %s with %d literals, want %s with %d", test.f, form.Format(5), form.Literals(), test.factored, test.literals)
+		}
+		if !equivalent(form, f, 5) {
+			t.Errorf("%s: %s differs from the sum", test.f, form.Format(5))
+		}
+	}
+}
+
+// Скобочная форма случайной суммы равна ей и не длиннее ее
+func TestFactorRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		f := randomSOP(random, 5)
+		form := Factor(f)
+		if !equivalent(form, f, 5) || form.Literals() > f.Literals() {
+			t.Fatalf("%s: %s with %d literals", f.Format(5), form.Format(5), form.Literals())
+		}
+	}
+}
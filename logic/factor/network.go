@@ -0,0 +1,198 @@
+package factor
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"sort"
+	"strings"
+)
+
+// Многоуровневая сеть: узлы y1, y2, ... и выход - суммы от входов
+// и других узлов без циклов
+type Network struct {
+	// Число входов x0..x(n-1); узел y_k - переменная Variables+k-1
+	Variables int
+	Nodes     []SOP
+	Output    SOP
+}
+
+// Функция создает сеть из одного выхода - двухуровневой суммы f
+func NewNetwork(variables int, f SOP) Network {
+	return Network{Variables: variables, Output: f}
+}
+
+// Функция возвращает суммы сети: узлы, затем выход
+func (n *Network) expressions() []*SOP {
+	expressions := make([]*SOP, 0, len(n.Nodes)+1)
+	for i := range n.Nodes {
+		expressions = append(expressions, &n.Nodes[i])
+	}
+	return append(expressions, &n.Output)
+}
+
+// Функция возвращает число литералов сумм сети
+func (n Network) Literals() int {
+	literals := 0
+	for _, f := range n.expressions() {
+		literals += f.Literals()
+	}
+	return literals
+}
+
+// Функция возвращает скобочные формы узлов и выхода
+func (n Network) Factor() []*Factored {
+	var forms []*Factored
+	for _, f := range n.expressions() {
+		forms = append(forms, Factor(*f))
+	}
+	return forms
+}
+
+// Функция возвращает число литералов скобочных форм сети
+func (n Network) FactoredLiterals() int {
+	literals := 0
+	for _, form := range n.Factor() {
+		literals += form.Literals()
+	}
+	return literals
+}
+
+// Функция вычисляет выход сети на наборе point; x0 - старший разряд
+// Узел может использовать узлы, созданные после него, поэтому значения
+// узлов вычисляются по требованию
+func (n Network) Value(point int) int {
+	values := make([]int, n.Variables+len(n.Nodes))
+	known := make([]bool, len(values))
+	for i := 0; i < n.Variables; i++ {
+		values[i] = point >> uint(n.Variables-1-i) & 1
+		known[i] = true
+	}
+	var evaluate func(f SOP) int
+	literal := func(l Literal) int {
+		v := l.Variable()
+		if !known[v] {
+			values[v] = evaluate(n.Nodes[v-n.Variables])
+			known[v] = true
+		}
+		if l.Negated() {
+			return 1 - values[v]
+		}
+		return values[v]
+	}
+	evaluate = func(f SOP) int {
+		for _, c := range f {
+			value := 1
+			for _, l := range c {
+				value &= literal(l)
+			}
+			if value == 1 {
+				return 1
+			}
+		}
+		return 0
+	}
+	return evaluate(n.Output)
+}
+
+// Функция проверяет выход сети на всех наборах spec
+// На неопределенных наборах допустимо любое значение
+func (n Network) Verify(spec logic.Spec) ([]logic.Mismatch, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if spec.Variables != n.Variables {
+		return nil, &logic.ArityError{Expected: n.Variables, Actual: spec.Variables}
+	}
+	var mismatches []logic.Mismatch
+	for point, expected := range spec.Values {
+		if expected == logic.DontCare {
+			continue
+		}
+		if actual := n.Value(point); actual != expected {
+			mismatches = append(mismatches, logic.Mismatch{
+				Point:    point,
+				Size:     spec.Variables,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+// Функция возвращает выигрыш в литералах от замены ядра k новым узлом
+// во всех суммах, которые оно делит: f = q·y + r вместо f, минус
+// литералы самого узла; сумма, равная k, не заменяется
+func (n Network) saving(k SOP) int {
+	saving := -k.Literals()
+	for _, f := range n.expressions() {
+		q, r := Divide(*f, k)
+		if len(q) == 0 || len(q) == 1 && len(q[0]) == 0 {
+			continue
+		}
+		saving += f.Literals() - (q.Literals() + len(q) + r.Literals())
+	}
+	return saving
+}
+
+// Функция выносит общие подвыражения: пока есть ядро какой-либо суммы,
+// замена которого новым узлом уменьшает число литералов сети, создает
+// узел с наибольшим выигрышем и подставляет его во все суммы, которые
+// ядро делит
+// Возвращает число созданных узлов
+func (n *Network) Extract() int {
+	created := 0
+	for {
+		seen := make(map[string]bool)
+		var candidates []SOP
+		for _, f := range n.expressions() {
+			for _, k := range Kernels(*f) {
+				if key := k.Kernel.key(); !seen[key] {
+					seen[key] = true
+					candidates = append(candidates, k.Kernel)
+				}
+			}
+		}
+		// Порядок кандидатов не зависит от обхода отображений
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].key() < candidates[j].key() })
+		var best SOP
+		bestSaving := 0
+		for _, k := range candidates {
+			if saving := n.saving(k); saving > bestSaving {
+				best, bestSaving = k, saving
+			}
+		}
+		if best == nil {
+			return created
+		}
+		y := Positive(n.Variables + len(n.Nodes))
+		for _, f := range n.expressions() {
+			q, r := Divide(*f, best)
+			if len(q) == 0 || len(q) == 1 && len(q[0]) == 0 {
+				continue
+			}
+			*f = append(q.Times(SOP{{y}}), r...).normalize()
+		}
+		n.Nodes = append(n.Nodes, best)
+		created++
+	}
+}
+
+// Функция записывает сеть суммами: "y1 = x2 + !x3", ..., "f = x1y1 + x4"
+func (n Network) String() string {
+	return n.format(func(f SOP) string { return f.Format(n.Variables) })
+}
+
+// Функция записывает сеть скобочными формами
+func (n Network) FactoredString() string {
+	return n.format(func(f SOP) string { return Factor(f).Format(n.Variables) })
+}
+
+func (n Network) format(expression func(SOP) string) string {
+	var lines []string
+	for k, node := range n.Nodes {
+		lines = append(lines, fmt.Sprintf("y%d = %s", k+1, expression(node)))
+	}
+	lines = append(lines, "f = "+expression(n.Output))
+	return strings.Join(lines, "\n")
+}
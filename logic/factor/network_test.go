@@ -0,0 +1,86 @@
+package factor
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"math/rand"
+	"testing"
+)
+
+// Функция строит сеть по минимальной ДНФ f, выносит подвыражения и
+// проверяет сеть на всех наборах
+func extract(t *testing.T, f []int) Network {
+	t.Helper()
+	spec := logic.NewSpec(f)
+	result, err := logic.Minimize(context.Background(), "qmc", spec, logic.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network := NewNetwork(spec.Variables, FromCover(result.Cover))
+	before := network.Literals()
+	network.Extract()
+	if mismatches, _ := network.Verify(spec); len(mismatches) != 0 {
+		t.Fatalf("%v:\n%s\n%v", f, network, mismatches)
+	}
+	if network.Literals() > before || network.FactoredLiterals() > network.Literals() {
+		t.Errorf("%v: %d literals before extraction, %d after, %d factored:\n%s",
+			f, before, network.Literals(), network.FactoredLiterals(), network)
+	}
+	return network
+}
+
+func TestExtractExhaustive(t *testing.T) {
+	f := make([]int, 8)
+	for vector := 0; vector < 1<<8; vector++ {
+		for point := range f {
+			f[point] = vector >> uint(point) & 1
+		}
+		extract(t, f)
+	}
+}
+
+func TestExtractRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		f := make([]int, 1<<uint(4+random.Intn(3)))
+		for point := range f {
+			f[point] = random.Intn(3)
+			if f[point] == 2 {
+				f[point] = logic.DontCare
+			}
+		}
+		extract(t, f)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		f        string
+		nodes    int
+		network  string
+		factored string
+	}{
+		// Вынос x2 + x3 после x0 + x1 не уменьшает числа литералов
+		{"x0x2 + x0x3 + x1x2 + x1x3 + x4", 1, "y1 = x0 + x1\nf = x2y1 + x3y1 + x4", "y1 = x0 + x1\nf = y1(x2 + x3) + x4"},
+		{"x0x3x5 + x0x4x5 + x1x3x5 + x1x4x5 + x2x3x5 + x2x4x5 + x6", 2,
+			"y1 = x0 + x1 + x2\ny2 = x3 + x4\nf = x5y1y2 + x6", "y1 = x0 + x1 + x2\ny2 = x3 + x4\nf = x5y1y2 + x6"},
+		{"x0x1 + x2x3", 0, "f = x0x1 + x2x3", "f = x0x1 + x2x3"},
+	}
+	for _, test := range tests {
+		network := NewNetwork(7, parse(test.f))
+		nodes := network.Extract()
+		if nodes != test.nodes || network.String() != test.network || network.FactoredString() != test.factored {
+			t.Errorf("%s: %d nodes\n%s\n%s", test.f, nodes, network, network.FactoredString())
+		}
+	}
+}
+
+func TestNetworkVerifyErrors(t *testing.T) {
+	network := NewNetwork(2, parse("x0x1"))
+	if _, err := network.Verify(logic.NewSpec([]int{0, 1, 1, 0, 1, 0, 0, 1})); err == nil {
+		t.Error("network of 2 variables verified on 3")
+	}
+	if _, err := network.Verify(logic.NewSpec([]int{0, 1, 1})); err == nil {
+		t.Error("network verified on a vector of length 3")
+	}
+}
@@ -0,0 +1,351 @@
+// Пакет factor - многоуровневая оптимизация ДНФ алгебраическими методами
+//
+// ДНФ рассматривается как алгебраический многочлен: литералы x и !x -
+// разные переменные, а кубы делятся друг на друга без булевых тождеств.
+// Пакет выполняет алгебраическое деление, находит ядра и коядра,
+// выносит общие подвыражения в узлы сети и печатает скобочные формы
+package factor
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Литерал: 2v для переменной v и 2v+1 для ее инверсии
+// Переменные 0..n-1 - входы x_i, переменные от n - узлы сети
+type Literal int
+
+// Функция возвращает литерал переменной v
+func Positive(v int) Literal {
+	return Literal(2 * v)
+}
+
+// Функция возвращает литерал инверсии переменной v
+func Negative(v int) Literal {
+	return Literal(2*v + 1)
+}
+
+// Функция возвращает номер переменной литерала
+func (l Literal) Variable() int {
+	return int(l) / 2
+}
+
+// Функция сообщает, что литерал - инверсия переменной
+func (l Literal) Negated() bool {
+	return l%2 == 1
+}
+
+// Функция записывает литерал: x_i для входов функции от n переменных
+// и y_k для узлов сети
+func (l Literal) Format(n int) string {
+	name := "x" + strconv.Itoa(l.Variable())
+	if l.Variable() >= n {
+		name = "y" + strconv.Itoa(l.Variable()-n+1)
+	}
+	if l.Negated() {
+		return "!" + name
+	}
+	return name
+}
+
+// Куб - произведение литералов по возрастанию
+type Cube []Literal
+
+// Функция сообщает, содержит ли куб все литералы куба d
+func (c Cube) Contains(d Cube) bool {
+	i := 0
+	for _, l := range d {
+		for i < len(c) && c[i] < l {
+			i++
+		}
+		if i == len(c) || c[i] != l {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция возвращает куб без литералов куба d
+func (c Cube) Without(d Cube) Cube {
+	result := Cube{}
+	for _, l := range c {
+		if !d.has(l) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// Функция возвращает произведение кубов
+func (c Cube) Times(d Cube) Cube {
+	result := append(Cube{}, c...)
+	for _, l := range d {
+		if !c.has(l) {
+			result = append(result, l)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+func (c Cube) has(l Literal) bool {
+	for _, m := range c {
+		if m == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Функция записывает куб: "x1!x3"; пустой куб - "1"
+func (c Cube) Format(n int) string {
+	if len(c) == 0 {
+		return "1"
+	}
+	var formatted string
+	for _, l := range c {
+		formatted += l.Format(n)
+	}
+	return formatted
+}
+
+func (c Cube) key() string {
+	parts := make([]string, len(c))
+	for i, l := range c {
+		parts[i] = strconv.Itoa(int(l))
+	}
+	return strings.Join(parts, ".")
+}
+
+// Алгебраическая сумма кубов (ДНФ)
+type SOP []Cube
+
+// Функция преобразует покрытие в ДНФ в алгебраическую сумму
+func FromCover(cover logic.Cover) SOP {
+	f := SOP{}
+	for _, cube := range cover.Cubes {
+		c := Cube{}
+		for i := 0; i < cover.Variables; i++ {
+			bit := uint32(1) << uint(i)
+			if cube.Mask&bit == 0 {
+				continue
+			}
+			if cube.Values&bit != 0 {
+				c = append(c, Positive(i))
+			} else {
+				c = append(c, Negative(i))
+			}
+		}
+		f = append(f, c)
+	}
+	return f.normalize()
+}
+
+// Функция преобразует импликанты qmc в алгебраическую сумму
+func FromTerms(variables int, terms []qmc.Term) SOP {
+	return FromCover(qmc.MakeCover(variables, terms))
+}
+
+// Функция упорядочивает кубы суммы и удаляет повторы
+func (f SOP) normalize() SOP {
+	seen := make(map[string]bool, len(f))
+	result := SOP{}
+	for _, c := range f {
+		if key := c.key(); !seen[key] {
+			seen[key] = true
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return less(result[i], result[j]) })
+	return result
+}
+
+// Порядок кубов: по литералам, как слова
+func less(a, b Cube) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+func (f SOP) key() string {
+	parts := make([]string, len(f))
+	for i, c := range f {
+		parts[i] = c.key()
+	}
+	return strings.Join(parts, "+")
+}
+
+// Функция возвращает число литералов суммы
+func (f SOP) Literals() int {
+	literals := 0
+	for _, c := range f {
+		literals += len(c)
+	}
+	return literals
+}
+
+// Функция записывает сумму: "x1x2 + !x3"; пустая сумма - "0"
+func (f SOP) Format(n int) string {
+	if len(f) == 0 {
+		return "0"
+	}
+	cubes := make([]string, len(f))
+	for i, c := range f {
+		cubes[i] = c.Format(n)
+	}
+	return strings.Join(cubes, " + ")
+}
+
+// Функция возвращает общий куб - литералы, входящие во все кубы суммы
+func (f SOP) CommonCube() Cube {
+	if len(f) == 0 {
+		return Cube{}
+	}
+	common := f[0]
+	for _, c := range f[1:] {
+		var next Cube
+		for _, l := range common {
+			if c.has(l) {
+				next = append(next, l)
+			}
+		}
+		common = next
+	}
+	return append(Cube{}, common...)
+}
+
+// Функция сообщает, что сумма свободна от кубов: в ней хотя бы два куба
+// и нет литерала, общего для всех
+func (f SOP) CubeFree() bool {
+	return len(f) > 1 && len(f.CommonCube()) == 0
+}
+
+// Функция делит сумму на куб: кубы, содержащие c, без литералов c
+func (f SOP) DivideCube(c Cube) SOP {
+	quotient := SOP{}
+	for _, cube := range f {
+		if cube.Contains(c) {
+			quotient = append(quotient, cube.Without(c))
+		}
+	}
+	return quotient.normalize()
+}
+
+// Функция возвращает произведение сумм
+func (f SOP) Times(g SOP) SOP {
+	product := SOP{}
+	for _, a := range f {
+		for _, b := range g {
+			product = append(product, a.Times(b))
+		}
+	}
+	return product.normalize()
+}
+
+// Функция возвращает сумму без кубов g
+func (f SOP) Minus(g SOP) SOP {
+	remove := make(map[string]bool, len(g))
+	for _, c := range g {
+		remove[c.key()] = true
+	}
+	result := SOP{}
+	for _, c := range f {
+		if !remove[c.key()] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Функция делит f на d алгебраически (слабое деление): f = q·d + r,
+// где q - наибольшее частное, в котором нет литералов d
+// Если d не делит f, частное пусто, а остаток равен f
+func Divide(f, d SOP) (quotient, remainder SOP) {
+	if len(d) == 0 {
+		return SOP{}, f
+	}
+	for i, di := range d {
+		q := f.DivideCube(di)
+		if i == 0 {
+			quotient = q
+			continue
+		}
+		keep := make(map[string]bool, len(q))
+		for _, c := range q {
+			keep[c.key()] = true
+		}
+		var next SOP
+		for _, c := range quotient {
+			if keep[c.key()] {
+				next = append(next, c)
+			}
+		}
+		quotient = next
+	}
+	if len(quotient) == 0 {
+		return SOP{}, f
+	}
+	return quotient, f.Minus(quotient.Times(d))
+}
+
+// Ядро суммы - частное от деления на куб (коядро), свободное от кубов
+type Kernel struct {
+	CoKernel Cube
+	Kernel   SOP
+}
+
+// Функция возвращает все ядра суммы с их коядрами; сама сумма входит
+// в список с пустым коядром, если свободна от кубов
+// Рекурсивный алгоритм перебирает литералы по возрастанию и пропускает
+// коядра, содержащие меньший литерал: такие ядра уже найдены
+func Kernels(f SOP) []Kernel {
+	var kernels []Kernel
+	seen := make(map[string]bool)
+	add := func(k Kernel) {
+		if key := k.CoKernel.key() + ":" + k.Kernel.key(); !seen[key] {
+			seen[key] = true
+			kernels = append(kernels, k)
+		}
+	}
+	common := f.CommonCube()
+	kernels1(f.DivideCube(common), common, 0, add)
+	return kernels
+}
+
+func kernels1(f SOP, coKernel Cube, start Literal, add func(Kernel)) {
+	literals := map[Literal]int{}
+	for _, c := range f {
+		for _, l := range c {
+			literals[l]++
+		}
+	}
+	var order []Literal
+	for l, count := range literals {
+		if count > 1 && l >= start {
+			order = append(order, l)
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+	for _, l := range order {
+		c := f.DivideCube(Cube{l}).CommonCube().Times(Cube{l})
+		skip := false
+		for _, m := range c {
+			if m < l {
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+		kernels1(f.DivideCube(c), coKernel.Times(c), l+1, add)
+	}
+	if f.CubeFree() {
+		add(Kernel{CoKernel: coKernel, Kernel: f})
+	}
+}
@@ -0,0 +1,157 @@
+package factor
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Функция читает сумму вида "x0x1!x2 + x3"; "0" - пустая сумма, "1" -
+// пустой куб
+func parse(s string) SOP {
+	f := SOP{}
+	if s == "0" {
+		return f
+	}
+	for _, term := range strings.Split(s, " + ") {
+		c := Cube{}
+		for i := 0; i < len(term); {
+			negated := term[i] == '!'
+			if negated {
+				i++
+			}
+			if term[i] == '1' {
+				i++
+				continue
+			}
+			j := i + 1
+			for j < len(term) && term[j] >= '0' && term[j] <= '9' {
+				j++
+			}
+			v, err := strconv.Atoi(term[i+1 : j])
+			if err != nil {
+				panic(err)
+			}
+			if negated {
+				c = append(c, Negative(v))
+			} else {
+				c = append(c, Positive(v))
+			}
+			i = j
+		}
+		f = append(f, Cube{}.Times(c))
+	}
+	return f.normalize()
+}
+
+// Функция вычисляет сумму на значениях переменных values
+func evaluate(f SOP, values []int) int {
+	for _, c := range f {
+		value := 1
+		for _, l := range c {
+			if l.Negated() {
+				value &= 1 - values[l.Variable()]
+			} else {
+				value &= values[l.Variable()]
+			}
+		}
+		if value == 1 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Функция возвращает случайную сумму от n переменных
+func randomSOP(random *rand.Rand, n int) SOP {
+	f := SOP{}
+	for i := random.Intn(6); i >= 0; i-- {
+		c := Cube{}
+		for v := 0; v < n; v++ {
+			switch random.Intn(3) {
+			case 0:
+				c = append(c, Positive(v))
+			case 1:
+				c = append(c, Negative(v))
+			}
+		}
+		f = append(f, c)
+	}
+	return f.normalize()
+}
+
+func TestDivide(t *testing.T) {
+	tests := []struct {
+		f, d                string
+		quotient, remainder string
+	}{
+		{"x0x2 + x0x3 + x1x2 + x1x3 + x4", "x0 + x1", "x2 + x3", "x4"},
+		{"x0x2 + x0x3 + x1x2 + x1x3 + x4", "x2 + x3", "x0 + x1", "x4"},
+		{"x0x1 + x0!x2 + x3", "x0", "x1 + !x2", "x3"},
+		{"x0x1 + x0!x2 + x3", "x1", "x0", "x0!x2 + x3"},
+		// Частное на x3 пусто, поэтому пусто и частное на x0 + x3
+		{"x0x1 + x2", "x0 + x3", "0", "x0x1 + x2"},
+		{"x0x1", "0", "0", "x0x1"},
+		{"x0x1 + x1", "x0 + 1", "x1", "0"},
+	}
+	for _, test := range tests {
+		quotient, remainder := Divide(parse(test.f), parse(test.d))
+		if quotient.Format(5) != test.quotient || remainder.normalize().Format(5) != test.remainder {
+			t.Errorf("(%s) / (%s) = %s, remainder %s; want %s, remainder %s",
+				test.f, test.d, quotient.Format(5), remainder.Format(5), test.quotient, test.remainder)
+		}
+	}
+}
+
+// Для случайных сумм f = q·d + r как многочлены, а частное не содержит
+// переменных делителя
+func TestDivideRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		f := randomSOP(random, 4)
+		var d SOP
+		if kernels := Kernels(f); len(kernels) != 0 && random.Intn(2) == 0 {
+			d = kernels[random.Intn(len(kernels))].Kernel
+		} else {
+			d = randomSOP(random, 4)
+		}
+		q, r := Divide(f, d)
+		if product := append(q.Times(d), r...).normalize(); product.key() != f.key() {
+			t.Fatalf("(%s) / (%s): q = %s, r = %s", f.Format(4), d.Format(4), q.Format(4), r.Format(4))
+		}
+		for _, c := range q {
+			for _, l := range c {
+				for _, dc := range d {
+					if dc.has(l) {
+						t.Fatalf("(%s) / (%s): quotient %s shares %s", f.Format(4), d.Format(4), q.Format(4), l.Format(4))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestKernels(t *testing.T) {
+	// Пример из учебника: adf + aef + bdf + bef + cdf + cef + g
+	f := parse("x0x3x5 + x0x4x5 + x1x3x5 + x1x4x5 + x2x3x5 + x2x4x5 + x6")
+	var got []string
+	for _, k := range Kernels(f) {
+		got = append(got, k.CoKernel.Format(7)+": "+k.Kernel.Format(7))
+		if !k.Kernel.CubeFree() || f.DivideCube(k.CoKernel).key() != k.Kernel.key() {
+			t.Errorf("co-kernel %s: %s is not a kernel", k.CoKernel.Format(7), k.Kernel.Format(7))
+		}
+	}
+	want := []string{
+		"x0x5: x3 + x4",
+		"x1x5: x3 + x4",
+		"x2x5: x3 + x4",
+		"x3x5: x0 + x1 + x2",
+		"x4x5: x0 + x1 + x2",
+		"x5: x0x3 + x0x4 + x1x3 + x1x4 + x2x3 + x2x4",
+		"1: x0x3x5 + x0x4x5 + x1x3x5 + x1x4x5 + x2x3x5 + x2x4x5 + x6",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("kernels\n%s", strings.Join(got, "\n"))
+	}
+}
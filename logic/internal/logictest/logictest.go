@@ -0,0 +1,56 @@
+// Пакет logictest содержит общие вспомогательные функции для тестов
+// алгоритмов минимизации: перебор ФАЛ и эталонную стоимость перебором
+package logictest
+
+import "github.com/AndreevSemen/asvt/logic"
+
+// Функция перебирает все ФАЛ от n переменных, с неопределенными наборами
+// либо без них
+func ForEachFunction(n int, dontCare bool, visit func(f []int)) {
+	values := []int{0, 1}
+	if dontCare {
+		values = append(values, logic.DontCare)
+	}
+	f := make([]int, 1<<uint(n))
+	var fill func(point int)
+	fill = func(point int) {
+		if point == len(f) {
+			visit(f)
+			return
+		}
+		for _, value := range values {
+			f[point] = value
+			fill(point + 1)
+		}
+	}
+	fill(0)
+}
+
+// Функция находит минимальную стоимость покрытия в форме form перебором
+// всех наборов кубов, поэтому годится только для n <= 2
+func BruteForceCost(f []int, n int, form logic.Form, model logic.CostModel) int {
+	var cubes []logic.Cube
+	for mask := uint32(0); mask < 1<<uint(n); mask++ {
+		for values := uint32(0); values < 1<<uint(n); values++ {
+			if values&^mask == 0 {
+				cubes = append(cubes, logic.Cube{Mask: mask, Values: values})
+			}
+		}
+	}
+	best := -1
+	for subset := 0; subset < 1<<uint(len(cubes)); subset++ {
+		cover := logic.Cover{Variables: n, Form: form}
+		for i, cube := range cubes {
+			if subset&(1<<uint(i)) != 0 {
+				cover.Cubes = append(cover.Cubes, cube)
+			}
+		}
+		if mismatches, _ := cover.Verify(f); len(mismatches) != 0 {
+			continue
+		}
+		if cost := cover.Cost(model); best == -1 || cost < best {
+			best = cost
+		}
+	}
+	return best
+}
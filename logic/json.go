@@ -0,0 +1,32 @@
+package logic
+
+// Покрытие в машиночитаемом виде
+// Куб записывается строкой, в которой символ i соответствует переменной x_i:
+// 0 и 1 - значение переменной, '-' - переменная не входит в куб
+// Для КНФ кубы описывают наборы, на которых дизъюнкты обращаются в ноль,
+// а для ESOP кубы складываются по модулю 2
+// Complexity - количество литералов, Cost - стоимость в модели CostModel
+type JSONCover struct {
+	Variables  int      `json:"variables"`
+	Form       string   `json:"form"`
+	Cubes      []string `json:"cubes"`
+	Complexity int      `json:"complexity"`
+	CostModel  string   `json:"cost_model"`
+	Cost       int      `json:"cost"`
+}
+
+// Функция преобразует покрытие в JSONCover со стоимостью в модели model
+func (c Cover) JSON(model CostModel) JSONCover {
+	result := JSONCover{
+		Variables:  c.Variables,
+		Form:       c.Form.String(),
+		Cubes:      make([]string, 0, len(c.Cubes)),
+		Complexity: c.Literals(),
+		CostModel:  model.Name(),
+		Cost:       c.Cost(model),
+	}
+	for _, cube := range c.Cubes {
+		result.Cubes = append(result.Cubes, cube.Notation(c.Variables))
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCubeNotation(t *testing.T) {
+	tests := []struct {
+		cube Cube
+		n    int
+		want string
+	}{
+		{Cube{}, 3, "---"},
+		{Cube{Mask: 1, Values: 1}, 3, "1--"},
+		{Cube{Mask: 6, Values: 2}, 3, "-10"},
+		{Cube{Mask: 15, Values: 9}, 4, "1001"},
+		{Cube{}, 0, ""},
+	}
+	for _, test := range tests {
+		if got := test.cube.Notation(test.n); got != test.want {
+			t.Errorf("%s: Notation(%d) = %q, want %q", test.cube.PrettyString(), test.n, got, test.want)
+		}
+	}
+}
+
+func TestCoverJSON(t *testing.T) {
+	tests := []struct {
+		cover Cover
+		model CostModel
+		want  string
+	}{
+		{
+			Cover{Variables: 2, Form: DNF, Cubes: []Cube{{Mask: 1, Values: 1}, {Mask: 2, Values: 2}}},
+			LiteralCost{},
+			`{"variables":2,"form":"dnf","cubes":["1-","-1"],"complexity":2,"cost_model":"literals","cost":2}`,
+		},
+		{
+			Cover{Variables: 3, Form: CNF, Cubes: []Cube{{Mask: 7}}},
+			TermCost{},
+			`{"variables":3,"form":"cnf","cubes":["000"],"complexity":3,"cost_model":"terms","cost":1}`,
+		},
+		{
+			Cover{Variables: 2, Form: ESOP, Cubes: []Cube{{}, {Mask: 3, Values: 1}}},
+			GateInputCost{},
+			`{"variables":2,"form":"esop","cubes":["--","10"],"complexity":2,"cost_model":"gates","cost":4}`,
+		},
+		// Пустое покрытие записывается пустым списком, а не null
+		{
+			Cover{Variables: 1, Form: DNF},
+			LiteralCost{},
+			`{"variables":1,"form":"dnf","cubes":[],"complexity":0,"cost_model":"literals","cost":0}`,
+		},
+	}
+	for _, test := range tests {
+		encoded, err := json.Marshal(test.cover.JSON(test.model))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(encoded) != test.want {
+			t.Errorf("%s: %s, want %s", test.cover.PrettyString(), encoded, test.want)
+		}
+		var decoded JSONCover
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Form != test.cover.Form.String() || len(decoded.Cubes) != len(test.cover.Cubes) {
+			t.Errorf("%s: decoded %+v", test.cover.PrettyString(), decoded)
+		}
+	}
+}
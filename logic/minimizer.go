@@ -0,0 +1,228 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Спецификация ФАЛ: значения на наборах 0..2^n-1, где x0 - старший разряд
+// номера набора; на неопределенных наборах значение равно DontCare
+type Spec struct {
+	Variables int
+	Values    []int
+}
+
+// Функция создает спецификацию по вектору значений
+// Вектор проверяет Validate; при длине не степени двойки
+// число переменных округляется вниз
+func NewSpec(values []int) Spec {
+	return Spec{
+		Variables: bits.Len(uint(len(values))) - 1,
+		Values:    values,
+	}
+}
+
+// Наибольшее число переменных: кубы хранят переменные в битах uint32
+const MaxVariables = 32
+
+// Функция разбирает вектор значений ФАЛ из символов '0', '1' и '-'
+// и проверяет получившуюся спецификацию
+func ParseSpec(s string) (Spec, error) {
+	f, err := ParseVector(s)
+	if err != nil {
+		return Spec{}, err
+	}
+	spec := NewSpec(f)
+	if err := spec.Validate(); err != nil {
+		return Spec{}, err
+	}
+	return spec, nil
+}
+
+// Функция проверяет, что длина вектора - степень двойки, соответствующая
+// числу переменных, а значения равны 0, 1 или DontCare
+func (s Spec) Validate() error {
+	variables, err := Arity(s.Values)
+	if err != nil {
+		return err
+	}
+	if variables != s.Variables {
+		return &ArityError{Expected: variables, Actual: s.Variables}
+	}
+	return nil
+}
+
+// Функция разбирает вектор значений ФАЛ из символов '0', '1' и '-'
+func ParseVector(s string) ([]int, error) {
+	f := make([]int, 0, len(s))
+	for _, char := range s {
+		switch char {
+		case '0':
+			f = append(f, 0)
+		case '1':
+			f = append(f, 1)
+		case '-':
+			f = append(f, DontCare)
+		default:
+			return nil, &ValueError{Point: len(f), Value: strconv.QuoteRune(char)}
+		}
+	}
+	return f, nil
+}
+
+// Функция возвращает номера наборов, на которых ФАЛ принимает значение value
+func (s Spec) Points(value int) []int {
+	var points []int
+	for point, v := range s.Values {
+		if v == value {
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+// Статистика работы алгоритма минимизации
+type Stats struct {
+	// Время работы
+	Duration time.Duration
+	// Количество кандидатов в покрытие: простых импликант либо коэффициентов
+	Candidates int
+	// Количество оцененных узлов перебора
+	Evaluated int64
+	// Причина остановки перебора: ErrBudgetExhausted или ошибка контекста;
+	// nil, если перебор завершен
+	Stopped error
+}
+
+// Параметры минимизации
+type Options struct {
+	// Модель стоимости, которую минимизирует алгоритм; nil - DefaultCost
+	Cost CostModel
+	// Бюджет перебора; по его исчерпании возвращается лучшее найденное
+	// покрытие без доказательства минимальности
+	Budget Budget
+	// Функция, которой периодически сообщается ход перебора; может быть nil
+	Progress func(Progress)
+	// Интервал отчетов о ходе перебора; 0 - DefaultProgressInterval
+	ProgressInterval time.Duration
+	// Число потоков параллельного перебора; 0 и 1 - последовательный перебор
+	// Результат от числа потоков не зависит, меняется только время
+	Workers int
+}
+
+// Функция возвращает выбранную модель стоимости
+func (o Options) CostModel() CostModel {
+	if o.Cost == nil {
+		return DefaultCost
+	}
+	return o.Cost
+}
+
+// Результат минимизации
+type Result struct {
+	Cover Cover
+	// Стоимость покрытия в модели, которую минимизировал алгоритм
+	Cost int
+	// Нижняя граница стоимости любого покрытия
+	LowerBound int
+	// Минимальность покрытия доказана: полным перебором
+	// либо совпадением стоимости с нижней границей
+	Optimal bool
+	Stats   Stats
+}
+
+// Функция возвращает разницу между стоимостью и нижней границей
+func (r Result) Gap() int {
+	return r.Cost - r.LowerBound
+}
+
+// Функция описывает, доказана ли минимальность покрытия
+func (r Result) Certificate() string {
+	status := "proven optimal"
+	if !r.Optimal {
+		status = "not proven optimal"
+		// Остановка перебора не мешает доказательству, если граница достигнута
+		if r.Stats.Stopped != nil {
+			status += " (" + r.Stats.Stopped.Error() + ")"
+		}
+	}
+	return fmt.Sprintf("%s, lower bound %d, gap %d", status, r.LowerBound, r.Gap())
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s (cost %d, %s, %d cubes, %d candidates, %v)",
+		r.Cover.PrettyString(), r.Cost, r.Certificate(), len(r.Cover.Cubes), r.Stats.Candidates, r.Stats.Duration)
+}
+
+// Алгоритм минимизации ФАЛ
+type Minimizer interface {
+	// Имя, под которым алгоритм регистрируется и выбирается
+	Name() string
+	// Функция минимизирует ФАЛ; некорректная спецификация - ошибка, а не паника
+	// При отмене ctx или исчерпании бюджета возвращается лучшее найденное
+	// покрытие с Optimal = false, а причина остановки записывается
+	// в Stats.Stopped; отмена ctx дополнительно возвращается как ошибка
+	Minimize(ctx context.Context, spec Spec, options Options) (Result, error)
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]Minimizer)
+)
+
+// Функция регистрирует алгоритм минимизации под его именем
+// Пакеты алгоритмов вызывают ее в init, как драйверы database/sql
+func Register(m Minimizer) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, found := registry[m.Name()]; found {
+		panic("logic: Register called twice for minimizer " + m.Name())
+	}
+	registry[m.Name()] = m
+}
+
+// Функция возвращает зарегистрированный алгоритм по имени
+func Lookup(name string) (Minimizer, error) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	m, found := registry[name]
+	if !found {
+		return nil, fmt.Errorf("unknown minimizer %q (known: %s)", name, strings.Join(namesLocked(), ", "))
+	}
+	return m, nil
+}
+
+// Функция возвращает имена зарегистрированных алгоритмов по алфавиту
+func Names() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return namesLocked()
+}
+
+// Функция минимизирует ФАЛ алгоритмом с именем name
+// Пакет алгоритма должен быть импортирован, чтобы он зарегистрировался
+func Minimize(ctx context.Context, name string, spec Spec, options Options) (Result, error) {
+	m, err := Lookup(name)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := spec.Validate(); err != nil {
+		return Result{}, err
+	}
+	return m.Minimize(ctx, spec, options)
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,121 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Алгоритм для проверки реестра: возвращает константу 1 и считает вызовы
+type constantMinimizer struct {
+	calls *int
+}
+
+func (constantMinimizer) Name() string { return "test-constant" }
+
+func (m constantMinimizer) Minimize(ctx context.Context, spec Spec, options Options) (Result, error) {
+	*m.calls++
+	cover := Cover{Variables: spec.Variables, Form: DNF, Cubes: []Cube{{}}}
+	return Result{Cover: cover, Cost: cover.Cost(options.CostModel())}, nil
+}
+
+// Функция регистрирует тестовый алгоритм на время теста
+// Реестр общий с примерами пакета, поэтому после теста алгоритм удаляется
+func registerConstant(t *testing.T) *int {
+	calls := new(int)
+	Register(constantMinimizer{calls: calls})
+	t.Cleanup(func() {
+		registryMutex.Lock()
+		defer registryMutex.Unlock()
+		delete(registry, "test-constant")
+	})
+	return calls
+}
+
+func TestRegistry(t *testing.T) {
+	calls := registerConstant(t)
+	m, err := Lookup("test-constant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name() != "test-constant" {
+		t.Errorf("Lookup returned %s", m.Name())
+	}
+	var found bool
+	for _, name := range Names() {
+		found = found || name == "test-constant"
+	}
+	if !found {
+		t.Errorf("Names() = %v", Names())
+	}
+
+	_, err = Lookup("missing")
+	if err == nil || !strings.Contains(err.Error(), `unknown minimizer "missing"`) || !strings.Contains(err.Error(), "test-constant") {
+		t.Errorf("Lookup(missing): %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("second Register did not panic")
+		}
+	}()
+	Register(constantMinimizer{calls: calls})
+}
+
+func TestMinimize(t *testing.T) {
+	calls := registerConstant(t)
+	tests := []struct {
+		spec Spec
+		// Ошибка, которую должен вернуть Minimize; nil - успех
+		target interface{}
+	}{
+		{NewSpec([]int{1, 1, DontCare, 1}), nil},
+		{Spec{Variables: 3, Values: []int{0, 1}}, new(*ArityError)},
+		{NewSpec([]int{0, 1, 1}), new(*LengthError)},
+		{NewSpec([]int{0, 5}), new(*ValueError)},
+	}
+	for _, test := range tests {
+		before := *calls
+		result, err := Minimize(context.Background(), "test-constant", test.spec, Options{})
+		if test.target == nil {
+			if err != nil {
+				t.Errorf("%v: %v", test.spec, err)
+			} else if result.Cover.PrettyString() != "1" || result.Cost != 0 {
+				t.Errorf("%v: result %v", test.spec, result)
+			}
+			continue
+		}
+		if !errors.As(err, test.target) {
+			t.Errorf("%v: error %v, want %T", test.spec, err, test.target)
+		}
+		// Некорректная спецификация не доходит до алгоритма
+		if *calls != before {
+			t.Errorf("%v: minimizer called on an invalid spec", test.spec)
+		}
+	}
+	if _, err := Minimize(context.Background(), "missing", NewSpec([]int{0, 1}), Options{}); err == nil {
+		t.Error("Minimize with an unknown minimizer succeeded")
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	tests := []struct {
+		result Result
+		want   string
+	}{
+		{Result{Cost: 5, LowerBound: 5, Optimal: true}, "proven optimal, lower bound 5, gap 0"},
+		{Result{Cost: 5, LowerBound: 3, Optimal: true}, "proven optimal, lower bound 3, gap 2"},
+		{Result{Cost: 5, LowerBound: 3}, "not proven optimal, lower bound 3, gap 2"},
+		{Result{Cost: 5, LowerBound: 3, Stats: Stats{Stopped: ErrBudgetExhausted}},
+			"not proven optimal (search budget exhausted), lower bound 3, gap 2"},
+		// Граница достигнута, поэтому остановка перебора не мешает доказательству
+		{Result{Cost: 4, LowerBound: 4, Optimal: true, Stats: Stats{Stopped: context.Canceled}},
+			"proven optimal, lower bound 4, gap 0"},
+	}
+	for _, test := range tests {
+		if got := test.result.Certificate(); got != test.want {
+			t.Errorf("Certificate() = %q, want %q", got, test.want)
+		}
+	}
+}
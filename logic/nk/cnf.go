@@ -0,0 +1,150 @@
+package nk
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+)
+
+// Нормальная форма, которую ищет метод неопределенных коэффициентов
+type Form int
+
+const (
+	// ДНФ: уравнения строятся по единичным наборам,
+	// а нулевые наборы обнуляют коэффициенты
+	DNF Form = iota
+	// КНФ: уравнения строятся по нулевым наборам,
+	// а единичные наборы исключают коэффициенты-дизъюнкты
+	CNF
+)
+
+// Функция разбора названия формы из командной строки
+func ParseForm(s string) (Form, error) {
+	switch s {
+	case "dnf":
+		return DNF, nil
+	case "cnf":
+		return CNF, nil
+	default:
+		return 0, fmt.Errorf("unknown form: %s", s)
+	}
+}
+
+// Функция строит систему уравнений без исключенных коэффициентов
+// для выбранной нормальной формы
+// Некорректный вектор f - ошибка logic.LengthError или logic.ValueError
+func MakeSystem(f []int, form Form) ([]Equation, error) {
+	if form == CNF {
+		return MakeSystemWithoutOnes(f)
+	}
+	return MakeSystemWithoutZeros(f)
+}
+
+// Функция проверяет, что ФАЛ хотя бы на одном наборе определена и на всех
+// определенных наборах равна 1 для ДНФ или 0 для КНФ
+// Такую ФАЛ записывает один пустой коэффициент (константа), которого нет
+// в системе: коэффициенты строятся только по непустым маскам
+func (form Form) IsConstant(f []int) bool {
+	excluded := form == CNF
+	defined := false
+	for _, value := range f {
+		v, isDefined := parseValue(value)
+		if !isDefined {
+			continue
+		}
+		if v == excluded {
+			return false
+		}
+		defined = true
+	}
+	return defined
+}
+
+// Функция строит двойственную систему для поиска минимальной КНФ
+// Уравнения составляются для нулевых наборов, а коэффициенты,
+// встречающиеся на единичных наборах, исключаются еще до построения
+func MakeSystemWithoutOnes(f []int) ([]Equation, error) {
+	return makeSystemWithout(f, true)
+}
+
+// Функция двойственна ExcludeZeroCoefficients: оставляет нулевые уравнения
+// полной системы и исключает из них коэффициенты единичных уравнений
+func ExcludeOneCoefficients(system []Equation) []Equation {
+	return excludeCoefficients(system, true)
+}
+
+// Функция преобразования коэффициента КНФ в дизъюнкт
+// Коэффициент нулевого набора обращается в ноль вместе с дизъюнктом,
+// поэтому переменная со значением 0 входит в дизъюнкт прямой, а со значением 1 - инвертированной
+// К пр.: K_(25)^(01) -> "(!x5 + x2)"
+func (a K) ClauseString() string {
+	vars := a.Vars()
+	var clause string
+	for i, v := range vars {
+		if i != 0 {
+			clause = " + " + clause
+		}
+		v.Value = !v.Value
+		clause = v.PrettyString() + clause
+	}
+	// Пустой дизъюнкт - константа 0
+	if len(vars) == 0 {
+		clause = "0"
+	}
+	if len(vars) > 1 {
+		clause = "(" + clause + ")"
+	}
+	return clause
+}
+
+// Функция форматирует коэффициенты в ДНФ либо в КНФ
+func (form Form) Format(ks []K) string {
+	if form == CNF {
+		return FormatCNF(ks)
+	}
+	return Format(ks)
+}
+
+// Функция форматирует дизъюнкты в КНФ
+// К пр.: [K_(25)^(01), K_(0)^(1)] -> "(!x5 + x2)!x0"
+func FormatCNF(ks []K) string {
+	// Пустая конъюнкция - константа 1
+	if len(ks) == 0 {
+		return "1"
+	}
+	var result string
+	for _, k := range ks {
+		result += k.ClauseString()
+	}
+	return result
+}
+
+// Функция преобразует коэффициенты в общее покрытие
+func (form Form) Cover(variables int, ks []K) logic.Cover {
+	cover := logic.Cover{
+		Variables: variables,
+		Form:      logic.DNF,
+		Cubes:     make([]logic.Cube, 0, len(ks)),
+	}
+	cover.Form = form.Logic()
+	for _, k := range ks {
+		cover.Cubes = append(cover.Cubes, k.Cube())
+	}
+	return cover
+}
+
+// Функция возвращает соответствующую форму общего покрытия
+func (form Form) Logic() logic.Form {
+	if form == CNF {
+		return logic.CNF
+	}
+	return logic.DNF
+}
+
+// Функция проверяет найденную форму на всех наборах функции f
+func (form Form) Verify(f []int, ks []K) ([]logic.Mismatch, error) {
+	variableNumber, err := logic.Arity(f)
+	if err != nil {
+		return nil, err
+	}
+	return form.Cover(variableNumber, ks).Verify(f)
+}
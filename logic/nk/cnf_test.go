@@ -0,0 +1,98 @@
+package nk
+
+import (
+	"testing"
+)
+
+func TestParseForm(t *testing.T) {
+	tests := []struct {
+		s    string
+		form Form
+		ok   bool
+	}{
+		{"", DNF, false},
+		{"dnf", DNF, true},
+		{"cnf", CNF, true},
+		{"esop", DNF, false},
+	}
+	for _, test := range tests {
+		form, err := ParseForm(test.s)
+		if (err == nil) != test.ok || test.ok && form != test.form {
+			t.Errorf("ParseForm(%q) = %v, %v", test.s, form, err)
+		}
+	}
+}
+
+// Двойственная система строится без единичных коэффициентов и совпадает
+// с полной системой после ExcludeOneCoefficients
+func TestMakeSystemWithoutOnes(t *testing.T) {
+	for n := 1; n <= 3; n++ {
+		forEachVector(n, func(f []int) {
+			full, err := MakeSystemOfEquations(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lazy, err := MakeSystemWithoutOnes(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := systemString(lazy), systemString(ExcludeOneCoefficients(full)); got != want {
+				t.Errorf("%v: system\n%s\nwant\n%s", f, got, want)
+			}
+			for _, equation := range lazy {
+				if equation.Value {
+					t.Fatalf("%v: equation %s of a one point", f, equation.KString())
+				}
+			}
+		})
+	}
+}
+
+// Система КНФ для f состоит из тех же коэффициентов, что и система ДНФ
+// для инверсии f
+func TestCNFDuality(t *testing.T) {
+	forEachVector(3, func(f []int) {
+		inverted := make([]int, len(f))
+		for i, value := range f {
+			inverted[i] = 1 - value
+		}
+		cnf, err := MakeSystem(f, CNF)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dnf, err := MakeSystem(inverted, DNF)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cnf) != len(dnf) {
+			t.Fatalf("%v: %d equations, %d for the inversion", f, len(cnf), len(dnf))
+		}
+		for i := range cnf {
+			if cnf[i].Term != dnf[i].Term || Format(cnf[i].Coefficients) != Format(dnf[i].Coefficients) {
+				t.Errorf("%v: equation %s, inversion %s", f, cnf[i].KString(), dnf[i].KString())
+			}
+		}
+	})
+}
+
+func TestFormatCNF(t *testing.T) {
+	tests := []struct {
+		ks   []K
+		want string
+	}{
+		{nil, "1"},
+		{[]K{{}}, "0"},
+		{[]K{{Mask: 1}}, "x0"},
+		{[]K{{Mask: 1, Values: 1}}, "!x0"},
+		{[]K{{Mask: 0x24, Values: 0x20}}, "(!x5 + x2)"},
+		{[]K{{Mask: 0x24, Values: 0x20}, {Mask: 1, Values: 1}}, "(!x5 + x2)!x0"},
+	}
+	for _, test := range tests {
+		if got := FormatCNF(test.ks); got != test.want {
+			t.Errorf("FormatCNF(%v) = %q, want %q", test.ks, got, test.want)
+		}
+		if got := CNF.Format(test.ks); got != test.want {
+			t.Errorf("CNF.Format(%v) = %q, want %q", test.ks, got, test.want)
+		}
+	}
+}
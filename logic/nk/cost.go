@@ -0,0 +1,30 @@
+package nk
+
+import "github.com/AndreevSemen/asvt/logic"
+
+// Модель стоимости коэффициентов для выбранной нормальной формы
+// Форма нужна моделям, учитывающим инверсии: в ДНФ и КНФ инвертируются
+// переменные с разными значениями в коэффициенте
+type Cost struct {
+	Model logic.CostModel
+	Form  Form
+}
+
+// Стоимость по умолчанию - количество литералов
+var DefaultCost = Cost{Model: logic.DefaultCost, Form: DNF}
+
+// Функция возвращает вклад коэффициента в стоимость решения
+// Сумма вкладов не превосходит стоимости решения, поэтому ее можно
+// использовать как нижнюю оценку при отсечении перебора
+func (c Cost) Of(k K) int {
+	return c.Model.CubeCost(c.Form.Logic(), k.Cube())
+}
+
+// Функция возвращает стоимость набора коэффициентов
+func (c Cost) Total(ks []K) int {
+	cubes := make([]logic.Cube, 0, len(ks))
+	for _, k := range ks {
+		cubes = append(cubes, k.Cube())
+	}
+	return c.Model.Cost(c.Form.Logic(), cubes)
+}
@@ -0,0 +1,50 @@
+package nk
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+)
+
+// Функция разбирает вектор значений ФАЛ вида "0110-1-0",
+// где '-' отмечает наборы, на которых ФАЛ не определена
+func ParseVector(s string) ([]int, error) {
+	return logic.ParseVector(s)
+}
+
+// Функция возвращает уравнения неопределенных наборов, в которые вошли
+// выбранные коэффициенты, оставляя в уравнениях только эти коэффициенты
+// На таких наборах найденная форма доопределяет ФАЛ: ДНФ единицей, КНФ нулем
+func CoveredUndefined(f []int, included []K) ([]Equation, error) {
+	variableNumber, err := logic.Arity(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var covered []Equation
+	for i := range f {
+		if _, defined := parseValue(f[i]); defined {
+			continue
+		}
+		term := NewTerm(i, variableNumber)
+		equation := Equation{
+			Term:      term,
+			Undefined: true,
+		}
+		for _, k := range included {
+			if k.IsIn(term) {
+				equation.Coefficients = append(equation.Coefficients, k)
+			}
+		}
+		if len(equation.Coefficients) != 0 {
+			covered = append(covered, equation)
+		}
+	}
+	return covered, nil
+}
+
+// Функция возвращает значение, которым форма доопределяет покрытые наборы
+func (form Form) CoveredValue() string {
+	if form == CNF {
+		return "0"
+	}
+	return "1"
+}
@@ -0,0 +1,107 @@
+package nk
+
+import (
+	"testing"
+)
+
+func TestParseVector(t *testing.T) {
+	f, err := ParseVector("01-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f) != 4 || f[0] != 0 || f[1] != 1 || f[2] != DontCare || f[3] != 1 {
+		t.Errorf("ParseVector(01-1) = %v", f)
+	}
+	if _, err := ParseVector("0x11"); err == nil {
+		t.Error("ParseVector(0x11) succeeded")
+	}
+}
+
+// Неопределенные наборы не дают уравнений и не исключают коэффициенты
+func TestDontCareSystem(t *testing.T) {
+	tests := []struct {
+		f      []int
+		form   Form
+		system string
+	}{
+		{[]int{0, 1, DontCare, 1}, DNF, "01: K_(1)^(1) + K_(01)^(01) = 1\n11: K_(0)^(1) + K_(1)^(1) + K_(01)^(11) = 1"},
+		{[]int{0, 1, 0, 1}, DNF, "01: K_(1)^(1) + K_(01)^(01) = 1\n11: K_(1)^(1) + K_(01)^(11) = 1"},
+		{[]int{0, 1, DontCare, 1}, CNF, "00: K_(1)^(0) + K_(01)^(00) = 0"},
+		{[]int{DontCare, DontCare}, DNF, ""},
+	}
+	for _, test := range tests {
+		system, err := MakeSystem(test.f, test.form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := systemString(system); got != test.system {
+			t.Errorf("%v %v: system\n%s\nwant\n%s", test.f, test.form, got, test.system)
+		}
+		full, err := MakeSystemOfEquations(test.f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		excluded := ExcludeZeroCoefficients(full)
+		if test.form == CNF {
+			excluded = ExcludeOneCoefficients(full)
+		}
+		if got := systemString(excluded); got != test.system {
+			t.Errorf("%v %v: excluded system\n%s\nwant\n%s", test.f, test.form, got, test.system)
+		}
+	}
+}
+
+func TestCoveredUndefined(t *testing.T) {
+	x0 := K{Mask: 1, Values: 1}
+	x1 := K{Mask: 2, Values: 2}
+	tests := []struct {
+		f        []int
+		included []K
+		covered  string
+	}{
+		{[]int{0, 1, DontCare, 1}, []K{x1}, ""},
+		{[]int{0, 1, DontCare, 1}, []K{x0, x1}, "10: K_(0)^(1) = -"},
+		{[]int{DontCare, 1, DontCare, 1}, []K{{}}, "00: K_()^() = -\n10: K_()^() = -"},
+		{[]int{DontCare, DontCare, 1, DontCare}, []K{x0}, "11: K_(0)^(1) = -"},
+	}
+	for _, test := range tests {
+		covered, err := CoveredUndefined(test.f, test.included)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := systemString(covered); got != test.covered {
+			t.Errorf("%v %s: covered\n%s\nwant\n%s", test.f, Format(test.included), got, test.covered)
+		}
+	}
+	if _, err := CoveredUndefined([]int{0, 1, 1}, nil); err == nil {
+		t.Error("CoveredUndefined accepted a vector of length 3")
+	}
+}
+
+// Найденная форма может доопределить ФАЛ на неопределенных наборах любым
+// значением, но на определенных совпадает с ней
+func TestDontCareVerify(t *testing.T) {
+	f := []int{0, 1, DontCare, 1}
+	for _, form := range []Form{DNF, CNF} {
+		for _, ks := range [][]K{{{Mask: 2, Values: 2}}, {{Mask: 2, Values: 2}, {Mask: 1, Values: 1}}} {
+			if form == CNF {
+				// Дизъюнкт x1 обращается в ноль на наборах с x1 = 0
+				ks = []K{{Mask: 2}}
+			}
+			mismatches, err := form.Verify(f, ks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(mismatches) != 0 {
+				t.Errorf("%v %s: %v", form, form.Format(ks), mismatches)
+			}
+		}
+	}
+	mismatches, err := DNF.Verify(f, []K{{Mask: 1, Values: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 1 || mismatches[0].Point != 1 {
+		t.Errorf("DNF x0: %v, want a false negative at 1", mismatches)
+	}
+}
@@ -0,0 +1,240 @@
+package nk
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"sort"
+)
+
+// Какие решения системы перечисляются помимо основного
+type Enumeration int
+
+const (
+	// Только основное решение
+	NoEnumeration Enumeration = iota
+	// Все решения минимальной сложности
+	AllMinimal
+	// Все безызбыточные (тупиковые) решения: ни один коэффициент
+	// нельзя убрать или укоротить
+	AllIrredundant
+)
+
+// Функция разбора вида перечисления из командной строки
+func ParseEnumeration(s string) (Enumeration, error) {
+	switch s {
+	case "":
+		return NoEnumeration, nil
+	case "minimal":
+		return AllMinimal, nil
+	case "irredundant":
+		return AllIrredundant, nil
+	default:
+		return 0, fmt.Errorf("unknown enumeration: %s", s)
+	}
+}
+
+// Функция приводит решение к каноническому порядку коэффициентов
+// и возвращает ключ, по которому совпадающие решения отбрасываются
+func canonical(result []K) ([]K, string) {
+	sorted := make([]K, len(result))
+	copy(sorted, result)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Rank() != b.Rank() {
+			return a.Rank() < b.Rank()
+		}
+		if a.Mask != b.Mask {
+			return a.Mask < b.Mask
+		}
+		return a.Values < b.Values
+	})
+	var key string
+	for _, k := range sorted {
+		key += k.KString()
+	}
+	return sorted, key
+}
+
+// Набор различных решений
+type variants struct {
+	found map[string]struct{}
+	list  [][]K
+}
+
+func (v *variants) add(result []K) {
+	sorted, key := canonical(result)
+	if _, found := v.found[key]; found {
+		return
+	}
+	v.found[key] = struct{}{}
+	v.list = append(v.list, sorted)
+}
+
+// Функция упорядочивает решения по стоимости, а равные - по записи
+func (v *variants) sorted(cost Cost) [][]K {
+	sort.SliceStable(v.list, func(i, j int) bool {
+		a, b := cost.Total(v.list[i]), cost.Total(v.list[j])
+		if a != b {
+			return a < b
+		}
+		return Format(v.list[i]) < Format(v.list[j])
+	})
+	return v.list
+}
+
+// Функция возвращает все наборы коэффициентов минимальной стоимости
+// Перебор тот же, что и в GetExactVariant, но ветви отсекаются только
+// при стоимости строго больше рекорда, чтобы не потерять равные решения
+// Решения составляются из простых коэффициентов, как и тупиковые:
+// непростой коэффициент заменяется своим подкоэффициентом без роста
+// стоимости. Систему нельзя упрощать с помощью Reduce: при равной
+// стоимости доминирование оставляет только одно из равных решений
+// Если tracker останавливает перебор, возвращаются найденные к этому
+// моменту решения
+func GetAllMinimalVariants(system []Equation, cost Cost, tracker *logic.Tracker) [][]K {
+	tracker.SetStage("all minimal solutions")
+	system = ExcludeNonPrimeCoefficients(system)
+	greedy := GetMinimalVariant(system, nil, cost, tracker)
+	bestComplexity := cost.Total(greedy)
+	all := &variants{found: make(map[string]struct{})}
+
+	var search func(system []Equation, result []K, complexity int)
+	search = func(system []Equation, result []K, complexity int) {
+		if !tracker.Step() {
+			return
+		}
+		if len(system) == 0 {
+			if complexity = cost.Total(result); complexity < bestComplexity {
+				bestComplexity = complexity
+				tracker.Found(complexity)
+				all = &variants{found: make(map[string]struct{})}
+			}
+			if complexity == bestComplexity {
+				all.add(result)
+			}
+			return
+		}
+		if complexity+LowerBound(system, cost) > bestComplexity {
+			return
+		}
+		for _, k := range shortestCandidates(system) {
+			next := append(result[:len(result):len(result)], k)
+			search(SolveBy(system, k), next, complexity+cost.Of(k))
+		}
+	}
+	search(system, nil, 0)
+	return all.sorted(cost)
+}
+
+// Функция возвращает все безызбыточные (тупиковые) наборы коэффициентов:
+// ни один коэффициент нельзя убрать или заменить его подкоэффициентом
+// Перебор ветвится по коэффициентам самого короткого нерешенного уравнения
+// и отсекает ветвь, как только один из выбранных коэффициентов становится
+// лишним: все решаемые им уравнения решены другими коэффициентами
+// Доминируемые, но простые коэффициенты могут входить в тупиковые решения,
+// поэтому систему нельзя упрощать с помощью Reduce
+// Решения упорядочиваются по стоимости cost
+// Если tracker останавливает перебор, возвращаются найденные к этому
+// моменту решения
+func GetAllIrredundantVariants(system []Equation, cost Cost, tracker *logic.Tracker) [][]K {
+	tracker.SetStage("all irredundant solutions")
+	all := &variants{found: make(map[string]struct{})}
+	system = ExcludeNonPrimeCoefficients(system)
+
+	isRedundant := func(result []K) bool {
+		for i, k := range result {
+			var isNeeded = false
+			for _, equation := range system {
+				if !k.IsIn(equation.Term) {
+					continue
+				}
+				var solvedByOther = false
+				for j, other := range result {
+					if i != j && other.IsIn(equation.Term) {
+						solvedByOther = true
+						break
+					}
+				}
+				if !solvedByOther {
+					isNeeded = true
+					break
+				}
+			}
+			if !isNeeded {
+				return true
+			}
+		}
+		return false
+	}
+
+	var search func(unsolved []Equation, result []K)
+	search = func(unsolved []Equation, result []K) {
+		if !tracker.Step() || isRedundant(result) {
+			return
+		}
+		if len(unsolved) == 0 {
+			all.add(result)
+			return
+		}
+		for _, k := range shortestCandidates(unsolved) {
+			next := append(result[:len(result):len(result)], k)
+			search(SolveBy(unsolved, k), next)
+		}
+	}
+	search(system, nil)
+	return all.sorted(cost)
+}
+
+// Функция возвращает коэффициенты самого короткого уравнения системы
+// в порядке возрастания ранга
+func shortestCandidates(system []Equation) []K {
+	shortest := system[0]
+	for _, equation := range system[1:] {
+		if len(equation.Coefficients) < len(shortest.Coefficients) {
+			shortest = equation
+		}
+	}
+	candidates := make([]K, len(shortest.Coefficients))
+	copy(candidates, shortest.Coefficients)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Less(candidates[j])
+	})
+	return candidates
+}
+
+// Функция исключает коэффициенты, у которых в системе есть подкоэффициент:
+// такой коэффициент можно укоротить, не потеряв ни одного уравнения
+func ExcludeNonPrimeCoefficients(system []Equation) []Equation {
+	present := make(map[K]struct{})
+	for _, equation := range system {
+		for _, k := range equation.Coefficients {
+			present[k] = struct{}{}
+		}
+	}
+
+	newSystem := make([]Equation, 0, len(system))
+	for _, equation := range system {
+		newEquation := equation
+		newEquation.Coefficients = nil
+		for _, k := range equation.Coefficients {
+			var isPrime = true
+			// Перебираем подкоэффициенты, убирая по одной переменной
+			for mask := k.Mask; mask != 0; mask &= mask - 1 {
+				bit := mask & -mask
+				sub := K{
+					Mask:   k.Mask &^ bit,
+					Values: k.Values &^ bit,
+				}
+				if _, found := present[sub]; found && sub.Mask != 0 {
+					isPrime = false
+					break
+				}
+			}
+			if isPrime {
+				newEquation.Coefficients = append(newEquation.Coefficients, k)
+			}
+		}
+		newSystem = append(newSystem, newEquation)
+	}
+	return newSystem
+}
@@ -0,0 +1,203 @@
+package nk
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"sort"
+	"testing"
+)
+
+func TestParseEnumeration(t *testing.T) {
+	tests := []struct {
+		s           string
+		enumeration Enumeration
+		ok          bool
+	}{
+		{"", NoEnumeration, true},
+		{"minimal", AllMinimal, true},
+		{"irredundant", AllIrredundant, true},
+		{"all", NoEnumeration, false},
+	}
+	for _, test := range tests {
+		enumeration, err := ParseEnumeration(test.s)
+		if (err == nil) != test.ok || test.ok && enumeration != test.enumeration {
+			t.Errorf("ParseEnumeration(%q) = %v, %v", test.s, enumeration, err)
+		}
+	}
+}
+
+// Функция перебором подмножеств простых коэффициентов находит
+// все минимальные и все безызбыточные решения системы
+func bruteForceVariants(system []Equation, cost Cost) (minimal, irredundant []string) {
+	set := make(map[K]struct{})
+	for _, equation := range ExcludeNonPrimeCoefficients(system) {
+		for _, k := range equation.Coefficients {
+			set[k] = struct{}{}
+		}
+	}
+	var primes []K
+	for k := range set {
+		primes = append(primes, k)
+	}
+	solves := func(result []K) bool {
+		for _, equation := range system {
+			var solved = false
+			for _, k := range result {
+				if k.IsIn(equation.Term) {
+					solved = true
+					break
+				}
+			}
+			if !solved {
+				return false
+			}
+		}
+		return true
+	}
+	best := -1
+	for subset := 0; subset < 1<<uint(len(primes)); subset++ {
+		var result []K
+		for i, k := range primes {
+			if subset&(1<<uint(i)) != 0 {
+				result = append(result, k)
+			}
+		}
+		if !solves(result) {
+			continue
+		}
+		var isIrredundant = true
+		for i := range result {
+			without := append(append([]K(nil), result[:i]...), result[i+1:]...)
+			if solves(without) {
+				isIrredundant = false
+				break
+			}
+		}
+		_, key := canonical(result)
+		if isIrredundant {
+			irredundant = append(irredundant, key)
+		}
+		switch complexity := cost.Total(result); {
+		case best == -1 || complexity < best:
+			best = complexity
+			minimal = []string{key}
+		case complexity == best:
+			minimal = append(minimal, key)
+		}
+	}
+	sort.Strings(minimal)
+	sort.Strings(irredundant)
+	return minimal, irredundant
+}
+
+func keys(results [][]K) []string {
+	var list []string
+	for _, result := range results {
+		_, key := canonical(result)
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Перечисление совпадает с перебором подмножеств на всех ФАЛ до 2 переменных,
+// в том числе с неопределенными наборами
+func TestEnumerateBruteForce(t *testing.T) {
+	tracker := logic.NewTracker(context.Background(), logic.Options{})
+	for n := 1; n <= 2; n++ {
+		size := 1 << uint(n)
+		count := 1
+		for i := 0; i < size; i++ {
+			count *= 3
+		}
+		for i := 0; i < count; i++ {
+			f := make([]int, size)
+			for point, rest := 0, i; point < size; point, rest = point+1, rest/3 {
+				f[point] = []int{0, 1, DontCare}[rest%3]
+			}
+			for _, form := range []Form{DNF, CNF} {
+				system, err := MakeSystem(f, form)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(system) == 0 || form.IsConstant(f) {
+					continue
+				}
+				for _, model := range costModels {
+					cost := Cost{Model: model, Form: form}
+					minimal, irredundant := bruteForceVariants(system, cost)
+					if got := keys(GetAllMinimalVariants(system, cost, tracker)); !equalStrings(got, minimal) {
+						t.Errorf("%v %v (%s): minimal %v, want %v", f, form, model.Name(), got, minimal)
+					}
+					if got := keys(GetAllIrredundantVariants(system, cost, tracker)); !equalStrings(got, irredundant) {
+						t.Errorf("%v %v (%s): irredundant %v, want %v", f, form, model.Name(), got, irredundant)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	tests := []struct {
+		vector               string
+		form                 Form
+		minimal, irredundant int
+		minimalCost          int
+	}{
+		// Циклическая ФАЛ: два минимальных покрытия из трех импликант
+		{"01111110", DNF, 2, 5, 6},
+		{"01111110", CNF, 1, 1, 6},
+		{"0111", DNF, 1, 1, 2},
+		{"0-11", DNF, 1, 1, 1},
+		// Простые коэффициенты !x0 и x1 решают одно и то же уравнение:
+		// оба решения минимальны, хотя Reduce оставил бы одно
+		{"-10-", DNF, 2, 2, 1},
+	}
+	tracker := logic.NewTracker(context.Background(), logic.Options{})
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		system, err := MakeSystem(spec.Values, test.form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cost := Cost{Model: logic.LiteralCost{}, Form: test.form}
+		minimal := GetAllMinimalVariants(system, cost, tracker)
+		irredundant := GetAllIrredundantVariants(system, cost, tracker)
+		if len(minimal) != test.minimal || len(irredundant) != test.irredundant {
+			t.Errorf("%s %v: %d minimal and %d irredundant solutions, want %d and %d",
+				test.vector, test.form, len(minimal), len(irredundant), test.minimal, test.irredundant)
+		}
+		for _, results := range [][][]K{minimal, irredundant} {
+			for _, result := range results {
+				if mismatches, _ := test.form.Verify(spec.Values, result); len(mismatches) != 0 {
+					t.Errorf("%s %v: %s: %v", test.vector, test.form, test.form.Format(result), mismatches)
+				}
+			}
+		}
+		for _, result := range minimal {
+			if got := cost.Total(result); got != test.minimalCost {
+				t.Errorf("%s %v: %s costs %d, want %d", test.vector, test.form, test.form.Format(result), got, test.minimalCost)
+			}
+		}
+		// Решения упорядочены по стоимости, и первое из тупиковых минимально
+		if len(irredundant) != 0 && cost.Total(irredundant[0]) != test.minimalCost {
+			t.Errorf("%s %v: cheapest irredundant %s", test.vector, test.form, test.form.Format(irredundant[0]))
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package nk
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"math"
+	"sort"
+)
+
+// Режим поиска минимального набора коэффициентов
+type Mode int
+
+const (
+	// Жадный выбор наиболее часто встречающегося коэффициента (быстрый режим)
+	Greedy Mode = iota
+	// Точный поиск методом ветвей и границ
+	Exact
+)
+
+// Функция разбора названия режима из командной строки
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "greedy":
+		return Greedy, nil
+	case "exact":
+		return Exact, nil
+	default:
+		return 0, fmt.Errorf("unknown mode: %s", s)
+	}
+}
+
+// Функция решает систему (уже без нулевых коэффициентов) в выбранном режиме,
+// минимизируя стоимость cost
+// Если tracker останавливает перебор, возвращается лучшее найденное решение
+func Solve(system []Equation, mode Mode, cost Cost, tracker *logic.Tracker) []K {
+	if mode == Greedy {
+		tracker.SetStage("greedy search")
+		return GetMinimalVariant(system, nil, cost, tracker)
+	}
+	return GetExactVariant(system, cost, tracker)
+}
+
+// Функция исключает из системы уравнения, которые решаются коэффициентом k
+func SolveBy(system []Equation, k K) []Equation {
+	newSystem := make([]Equation, 0, len(system))
+	for _, equation := range system {
+		if !k.IsIn(equation.Term) {
+			newSystem = append(newSystem, equation)
+		}
+	}
+	return newSystem
+}
+
+// Функция оценивает снизу стоимость решения оставшейся системы
+// Жадно набираем попарно непересекающиеся уравнения (без общих коэффициентов):
+// каждое из них требует собственного коэффициента, поэтому сумма минимальных
+// вкладов коэффициентов этих уравнений не превосходит стоимости любого решения
+func LowerBound(system []Equation, cost Cost) int {
+	order := make([]int, len(system))
+	for i := range order {
+		order[i] = i
+	}
+	// Короткие уравнения сильнее ограничивают решение, поэтому берем их первыми
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(system[order[i]].Coefficients) < len(system[order[j]].Coefficients)
+	})
+
+	used := make(map[K]struct{})
+	bound := 0
+	for _, index := range order {
+		equation := system[index]
+		var isDisjoint = true
+		for _, k := range equation.Coefficients {
+			if _, found := used[k]; found {
+				isDisjoint = false
+				break
+			}
+		}
+		if !isDisjoint {
+			continue
+		}
+		minCost := math.MaxInt32
+		for _, k := range equation.Coefficients {
+			used[k] = struct{}{}
+			if cost.Of(k) < minCost {
+				minCost = cost.Of(k)
+			}
+		}
+		bound += minCost
+	}
+	return bound
+}
+
+// Состояние поиска методом ветвей и границ
+// Общие поля только читаются, поэтому ветви перебора можно выполнять
+// параллельно; рекорд общий для всех ветвей
+type branchAndBound struct {
+	cost    Cost
+	tracker *logic.Tracker
+	bound   *logic.SharedBound
+}
+
+// Узел перебора: нерешенная часть системы и выбранные коэффициенты
+type bbNode struct {
+	system     []Equation
+	result     []K
+	complexity int
+}
+
+// Лучшее решение, найденное в одной ветви перебора
+type bbBranch struct {
+	best []K
+}
+
+// Сколько ветвей приходится на поток, чтобы потоки загружались равномерно
+const branchesPerWorker = 8
+
+// Функция возвращает набор коэффициентов минимальной стоимости
+// Начальным рекордом служит результат жадного алгоритма, далее перебор
+// ветвится по коэффициентам самого короткого нерешенного уравнения и
+// отсекает ветви, нижняя граница которых не лучше рекорда
+// complexity в переборе - сумма вкладов выбранных коэффициентов, а стоимость
+// готового решения считается целиком, так как модель может быть не аддитивной
+// Верхние уровни дерева перебора раскрываются в ветви, которые раздаются
+// потокам tracker.Workers(); ветви нумеруются в порядке последовательного
+// перебора, поэтому из равных по стоимости решений выбирается одно и то же
+// при любом числе потоков
+func GetExactVariant(system []Equation, cost Cost, tracker *logic.Tracker) []K {
+	tracker.SetStage("greedy initial bound")
+	greedy := GetMinimalVariant(system, nil, cost, tracker)
+	tracker.SetStage("branch and bound")
+	bb := &branchAndBound{
+		cost:    cost,
+		tracker: tracker,
+		bound:   logic.NewSharedBound(),
+	}
+	// Жадное решение - ветвь 0, она выигрывает у остальных при равной стоимости
+	bb.bound.Improve(cost.Total(greedy), 0)
+
+	frontier := []bbNode{{system: system}}
+	workers := tracker.Workers()
+	for workers > 1 && len(frontier) < workers*branchesPerWorker {
+		next := bb.expand(frontier)
+		if len(next) == len(frontier) {
+			break
+		}
+		frontier = next
+	}
+	branches := make([]bbBranch, len(frontier))
+	logic.RunBranches(len(frontier), workers, func(i int) {
+		node := frontier[i]
+		bb.search(&branches[i], i+1, node.system, node.result, node.complexity)
+	})
+	if _, branch := bb.bound.Best(); branch != 0 {
+		return branches[branch-1].best
+	}
+	return greedy
+}
+
+// Функция заменяет узлы их потомками с сохранением порядка перебора
+func (bb *branchAndBound) expand(frontier []bbNode) []bbNode {
+	var next []bbNode
+	for _, node := range frontier {
+		if len(node.system) == 0 {
+			next = append(next, node)
+			continue
+		}
+		for _, k := range shortestCandidates(node.system) {
+			next = append(next, bbNode{
+				system:     SolveBy(node.system, k),
+				result:     append(node.result[:len(node.result):len(node.result)], k),
+				complexity: node.complexity + bb.cost.Of(k),
+			})
+		}
+	}
+	return next
+}
+
+func (bb *branchAndBound) search(b *bbBranch, branch int, system []Equation, result []K, complexity int) {
+	if !bb.tracker.Step() {
+		return
+	}
+	if len(system) == 0 {
+		complexity = bb.cost.Total(result)
+		if bb.bound.Improve(complexity, branch) {
+			b.best = append([]K(nil), result...)
+			bb.tracker.Found(complexity)
+		}
+		return
+	}
+	if bb.bound.Prunes(complexity+LowerBound(system, bb.cost), branch) {
+		return
+	}
+
+	// Любое решение обязано решить самое короткое уравнение,
+	// поэтому достаточно перебрать только его коэффициенты
+	candidates := shortestCandidates(system)
+
+	for _, k := range candidates {
+		next := append(result[:len(result):len(result)], k)
+		bb.search(b, branch, SolveBy(system, k), next, complexity+bb.cost.Of(k))
+	}
+}
@@ -0,0 +1,51 @@
+package nk
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"testing"
+)
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		s    string
+		mode Mode
+		ok   bool
+	}{
+		{"exact", Exact, true},
+		{"greedy", Greedy, true},
+		{"fast", Greedy, false},
+	}
+	for _, test := range tests {
+		mode, err := ParseMode(test.s)
+		if (err == nil) != test.ok || test.ok && mode != test.mode {
+			t.Errorf("ParseMode(%q) = %v, %v", test.s, mode, err)
+		}
+	}
+}
+
+// Нижняя граница не превосходит стоимости точного решения, а жадное
+// решение не дешевле точного
+func TestLowerBound(t *testing.T) {
+	tracker := logic.NewTracker(context.Background(), logic.Options{})
+	logictest.ForEachFunction(3, true, func(f []int) {
+		for _, form := range []Form{DNF, CNF} {
+			if form.IsConstant(f) {
+				continue
+			}
+			system, err := MakeSystem(f, form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, model := range []logic.CostModel{logic.LiteralCost{}, logic.TransistorCost{}} {
+				cost := Cost{Model: model, Form: form}
+				exact := cost.Total(GetExactVariant(system, cost, tracker))
+				greedy := cost.Total(GetMinimalVariant(system, nil, cost, tracker))
+				if bound := LowerBound(system, cost); bound > exact || exact > greedy {
+					t.Fatalf("%v %v (%s): bound %d, exact %d, greedy %d", f, form, model.Name(), bound, exact, greedy)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,255 @@
+package nk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// Формат выгрузки этапов метода
+type ExportFormat int
+
+const (
+	// Текстовые файлы, как all_equations.txt и without_zeros.txt
+	TextFormat ExportFormat = iota
+	// Окружение array для вставки в .tex
+	LatexFormat
+	// JSON для дальнейшей обработки
+	JSONFormat
+)
+
+// Функция разбора названия формата из командной строки
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch s {
+	case "text":
+		return TextFormat, nil
+	case "latex":
+		return LatexFormat, nil
+	case "json":
+		return JSONFormat, nil
+	default:
+		return 0, fmt.Errorf("unknown export format: %s", s)
+	}
+}
+
+// Функция возвращает расширение файлов формата
+func (format ExportFormat) Extension() string {
+	switch format {
+	case LatexFormat:
+		return ".tex"
+	case JSONFormat:
+		return ".json"
+	default:
+		return ".txt"
+	}
+}
+
+// Этап метода: название (оно же имя файла) и система уравнений
+type Stage struct {
+	Name   string
+	System []Equation
+}
+
+// Функция возвращает этапы метода: полную систему, систему без
+// исключенных коэффициентов и результирующую систему из ExcludeOther
+func MakeStages(f []int, form Form, system []Equation, result []K) ([]Stage, error) {
+	all, err := MakeSystemOfEquations(f)
+	if err != nil {
+		return nil, err
+	}
+	excludedName := "without_zeros"
+	if form == CNF {
+		excludedName = "without_ones"
+	}
+	return []Stage{
+		{Name: "all_equations", System: all},
+		{Name: excludedName, System: system},
+		{Name: "equations_with_minimal_coefficients", System: ExcludeOther(system, result)},
+	}, nil
+}
+
+// Функция записывает каждый этап в отдельный файл каталога dir
+func Export(dir string, format ExportFormat, stages []Stage) error {
+	for _, stage := range stages {
+		file, err := os.Create(filepath.Join(dir, stage.Name+format.Extension()))
+		if err != nil {
+			return err
+		}
+		err = WriteStage(file, format, stage)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Функция записывает этап в выбранном формате
+func WriteStage(w io.Writer, format ExportFormat, stage Stage) error {
+	switch format {
+	case LatexFormat:
+		return writeLatex(w, stage)
+	case JSONFormat:
+		return writeJSON(w, stage)
+	default:
+		return writeText(w, stage)
+	}
+}
+
+// Переменные коэффициента в нумерации домашнего задания: x1 - младший
+// разряд номера набора, переменные перечисляются от старшей к младшей
+// К пр.: для 6 переменных K_(0345)^(0011) -> [6 3 2 1], "0011"
+func paperVars(k K, size int) ([]int, string) {
+	var numbers []int
+	var values string
+	for _, v := range k.Vars() {
+		numbers = append(numbers, size-v.Number)
+		if v.Value {
+			values += "1"
+		} else {
+			values += "0"
+		}
+	}
+	return numbers, values
+}
+
+// Функция упорядочивает коэффициенты как в домашнем задании:
+// по возрастанию ранга, а внутри ранга лексикографически
+// по номерам переменных от младшей к старшей; коэффициенты ранга 1
+// в домашнем задании идут в обратном порядке: K_6^0 ∨ ... ∨ K_1^0
+func paperOrder(ks []K, size int) []K {
+	ordered := make([]K, len(ks))
+	copy(ordered, ks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, _ := paperVars(ordered[i], size)
+		b, _ := paperVars(ordered[j], size)
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		if len(a) == 1 {
+			return a[0] > b[0]
+		}
+		for l := 1; l <= len(a); l++ {
+			if a[len(a)-l] != b[len(b)-l] {
+				return a[len(a)-l] < b[len(b)-l]
+			}
+		}
+		return false
+	})
+	return ordered
+}
+
+// Функция возвращает значение уравнения: 0, 1 либо - для неопределенного набора
+func valueString(e Equation) string {
+	switch {
+	case e.Undefined:
+		return "-"
+	case e.Value:
+		return "1"
+	default:
+		return "0"
+	}
+}
+
+// Функция записывает коэффициент функции от size переменных
+// в нумерации домашнего задания: K_4321^0011
+func paperString(k K, size int) string {
+	numbers, values := paperVars(k, size)
+	formatted := "K_"
+	for _, number := range numbers {
+		formatted += strconv.Itoa(number)
+	}
+	return formatted + "^" + values
+}
+
+// Формат строки: K_4321^0011 ∨ K_5321^0011 = f(000011) = 1
+func writeText(w io.Writer, stage Stage) error {
+	for _, e := range stage.System {
+		var line string
+		for i, k := range paperOrder(e.Coefficients, e.Term.Size) {
+			if i != 0 {
+				line += " ∨ "
+			}
+			line += paperString(k, e.Term.Size)
+		}
+		line += fmt.Sprintf(" = f(%s) = %s\n", e.Term, valueString(e))
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Формат строки: K_{4321}^{0011} \vee K_{5321}^{0011} = f(000011) = 1 \\
+func writeLatex(w io.Writer, stage Stage) error {
+	if _, err := io.WriteString(w, "\\begin{array}{l}\n"); err != nil {
+		return err
+	}
+	for _, e := range stage.System {
+		var line string
+		for i, k := range paperOrder(e.Coefficients, e.Term.Size) {
+			if i != 0 {
+				line += " \\vee "
+			}
+			numbers, values := paperVars(k, e.Term.Size)
+			line += "K_{"
+			for _, number := range numbers {
+				line += strconv.Itoa(number)
+			}
+			line += "}^{" + values + "}"
+		}
+		line += fmt.Sprintf(" = f(%s) = %s \\\\\n", e.Term, valueString(e))
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\\end{array}\n")
+	return err
+}
+
+type jsonCoefficient struct {
+	Variables []int  `json:"variables"`
+	Values    string `json:"values"`
+}
+
+type jsonEquation struct {
+	Point        string            `json:"point"`
+	Value        string            `json:"value"`
+	Coefficients []jsonCoefficient `json:"coefficients"`
+}
+
+type jsonStage struct {
+	Stage     string         `json:"stage"`
+	Equations []jsonEquation `json:"equations"`
+}
+
+func writeJSON(w io.Writer, stage Stage) error {
+	exported := jsonStage{
+		Stage:     stage.Name,
+		Equations: make([]jsonEquation, 0, len(stage.System)),
+	}
+	for _, e := range stage.System {
+		equation := jsonEquation{
+			Point:        e.Term.String(),
+			Value:        valueString(e),
+			Coefficients: make([]jsonCoefficient, 0, len(e.Coefficients)),
+		}
+		for _, k := range paperOrder(e.Coefficients, e.Term.Size) {
+			numbers, values := paperVars(k, e.Term.Size)
+			equation.Coefficients = append(equation.Coefficients, jsonCoefficient{
+				Variables: numbers,
+				Values:    values,
+			})
+		}
+		exported.Equations = append(exported.Equations, equation)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exported)
+}
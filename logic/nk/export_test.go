@@ -0,0 +1,97 @@
+package nk_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/AndreevSemen/asvt/logic/nk"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Этапы метода для f = x0 + x1
+func stages(t *testing.T) []nk.Stage {
+	t.Helper()
+	f := []int{0, 1, 1, 1}
+	system, err := nk.MakeSystem(f, nk.DNF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := []nk.K{{Mask: 1, Values: 1}, {Mask: 2, Values: 2}}
+	stages, err := nk.MakeStages(f, nk.DNF, system, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stages
+}
+
+func TestWriteStage(t *testing.T) {
+	tests := []struct {
+		format nk.ExportFormat
+		stage  int
+		want   string
+	}{
+		{nk.TextFormat, 0, "K_2^0 ∨ K_1^0 ∨ K_21^00 = f(00) = 0\n" +
+			"K_2^0 ∨ K_1^1 ∨ K_21^01 = f(01) = 1\n" +
+			"K_2^1 ∨ K_1^0 ∨ K_21^10 = f(10) = 1\n" +
+			"K_2^1 ∨ K_1^1 ∨ K_21^11 = f(11) = 1\n"},
+		{nk.TextFormat, 1, "K_1^1 ∨ K_21^01 = f(01) = 1\n" +
+			"K_2^1 ∨ K_21^10 = f(10) = 1\n" +
+			"K_2^1 ∨ K_1^1 ∨ K_21^11 = f(11) = 1\n"},
+		{nk.LatexFormat, 2, "\\begin{array}{l}\n" +
+			"K_{1}^{1} = f(01) = 1 \\\\\n" +
+			"K_{2}^{1} = f(10) = 1 \\\\\n" +
+			"K_{2}^{1} \\vee K_{1}^{1} = f(11) = 1 \\\\\n" +
+			"\\end{array}\n"},
+	}
+	stages := stages(t)
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		if err := nk.WriteStage(&buffer, test.format, stages[test.stage]); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != test.want {
+			t.Errorf("stage %s%s:\n%s\nwant:\n%s", stages[test.stage].Name, test.format.Extension(), buffer.String(), test.want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	stages := stages(t)
+	for _, format := range []nk.ExportFormat{nk.TextFormat, nk.LatexFormat, nk.JSONFormat} {
+		dir := t.TempDir()
+		if err := nk.Export(dir, format, stages); err != nil {
+			t.Fatal(err)
+		}
+		for _, stage := range stages {
+			data, err := os.ReadFile(filepath.Join(dir, stage.Name+format.Extension()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != nk.JSONFormat {
+				continue
+			}
+			var exported struct {
+				Stage     string
+				Equations []struct {
+					Point        string
+					Value        string
+					Coefficients []struct {
+						Variables []int
+						Values    string
+					}
+				}
+			}
+			if err := json.Unmarshal(data, &exported); err != nil {
+				t.Fatal(err)
+			}
+			if exported.Stage != stage.Name || len(exported.Equations) != len(stage.System) {
+				t.Errorf("%s.json: stage %q with %d equations, want %q with %d",
+					stage.Name, exported.Stage, len(exported.Equations), stage.Name, len(stage.System))
+			}
+		}
+	}
+	if err := nk.Export(filepath.Join(t.TempDir(), "missing"), nk.TextFormat, stages); err == nil {
+		t.Error("export to a missing directory succeeded")
+	}
+}
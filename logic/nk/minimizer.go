@@ -0,0 +1,86 @@
+package nk
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"io/ioutil"
+	"time"
+)
+
+// Метод неопределенных коэффициентов как алгоритм минимизации
+type Minimizer struct {
+	Mode Mode
+	Form Form
+}
+
+func init() {
+	logic.Register(Minimizer{Mode: Exact, Form: DNF})
+	logic.Register(Minimizer{Mode: Greedy, Form: DNF})
+	logic.Register(Minimizer{Mode: Exact, Form: CNF})
+}
+
+// Имена: nk - точная ДНФ, nk-greedy - жадная ДНФ, nk-cnf - точная КНФ
+func (m Minimizer) Name() string {
+	name := "nk"
+	if m.Mode == Greedy {
+		name += "-greedy"
+	}
+	if m.Form == CNF {
+		name += "-cnf"
+	}
+	return name
+}
+
+func (m Minimizer) Minimize(ctx context.Context, spec logic.Spec, options logic.Options) (logic.Result, error) {
+	if err := spec.Validate(); err != nil {
+		return logic.Result{}, err
+	}
+	start := time.Now()
+	cost := Cost{Model: options.CostModel(), Form: m.Form}
+	if m.Form.IsConstant(spec.Values) {
+		// Константу записывает один пустой куб, и дешевле покрытия нет
+		result := []K{{}}
+		total := cost.Total(result)
+		return logic.Result{
+			Cover:      m.Form.Cover(spec.Variables, result),
+			Cost:       total,
+			LowerBound: total,
+			Optimal:    true,
+			Stats: logic.Stats{
+				Duration:   time.Since(start),
+				Candidates: 1,
+			},
+		}, nil
+	}
+	system, err := MakeSystem(spec.Values, m.Form)
+	if err != nil {
+		return logic.Result{}, err
+	}
+	candidates := make(map[K]struct{})
+	for _, equation := range system {
+		for _, k := range equation.Coefficients {
+			candidates[k] = struct{}{}
+		}
+	}
+	reduced := Reduce(system, cost, ioutil.Discard)
+	tracker := logic.NewTracker(ctx, options)
+	result := Solve(reduced, m.Mode, cost, tracker)
+	total := cost.Total(result)
+	bound := LowerBound(reduced, cost)
+
+	cover := m.Form.Cover(spec.Variables, result)
+	return logic.Result{
+		Cover:      cover,
+		Cost:       total,
+		LowerBound: bound,
+		// Упрощение сохраняет минимальные решения, а точный режим
+		// перебирает все решения упрощенной системы, если его не остановили
+		Optimal: m.Mode == Exact && tracker.Err() == nil || total == bound,
+		Stats: logic.Stats{
+			Duration:   time.Since(start),
+			Candidates: len(candidates),
+			Evaluated:  tracker.Evaluated(),
+			Stopped:    tracker.Err(),
+		},
+	}, logic.CanceledError(tracker.Err())
+}
@@ -0,0 +1,145 @@
+package nk_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AndreevSemen/asvt/logic"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"testing"
+)
+
+// Функция перечисляет все ФАЛ от n переменных; при dontCare значения
+// перебираются из 0, 1 и DontCare
+func functions(n int, dontCare bool) [][]int {
+	values := []int{0, 1}
+	if dontCare {
+		values = append(values, logic.DontCare)
+	}
+	all := [][]int{{}}
+	for point := 0; point < 1<<uint(n); point++ {
+		var next [][]int
+		for _, f := range all {
+			for _, v := range values {
+				next = append(next, append(f[:len(f):len(f)], v))
+			}
+		}
+		all = next
+	}
+	return all
+}
+
+// Функция возвращает ФАЛ с инвертированными определенными значениями
+func complement(f []int) []int {
+	inverted := make([]int, len(f))
+	for i, v := range f {
+		inverted[i] = v
+		if v != logic.DontCare {
+			inverted[i] = 1 - v
+		}
+	}
+	return inverted
+}
+
+func minimize(t *testing.T, backend string, f []int, cost logic.CostModel) logic.Result {
+	t.Helper()
+	result, err := logic.Minimize(context.Background(), backend, logic.NewSpec(f), logic.Options{Cost: cost})
+	if err != nil {
+		t.Fatalf("%s %v: %v", backend, f, err)
+	}
+	mismatches, err := result.Cover.Verify(f)
+	if err != nil {
+		t.Fatalf("%s %v: %v", backend, f, err)
+	}
+	if len(mismatches) != 0 {
+		t.Fatalf("%s %v: cover %s has mismatches %v", backend, f, result.Cover.PrettyString(), mismatches)
+	}
+	return result
+}
+
+func TestConstants(t *testing.T) {
+	tests := []struct {
+		backend string
+		vector  string
+		cover   string
+		cubes   int
+	}{
+		{"nk", "1111", "1", 1},
+		{"nk", "11-1", "1", 1},
+		{"nk", "0000", "0", 0},
+		{"nk", "----", "0", 0},
+		{"nk-greedy", "11111111", "1", 1},
+		{"nk-greedy", "00000000", "0", 0},
+		{"nk-cnf", "0000", "0", 1},
+		{"nk-cnf", "0-00", "0", 1},
+		{"nk-cnf", "1111", "1", 0},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := minimize(t, test.backend, spec.Values, nil)
+		if result.Cost != 0 || !result.Optimal || result.LowerBound != 0 {
+			t.Errorf("%s %s: cost %d, %s, want cost 0 proven optimal", test.backend, test.vector, result.Cost, result.Certificate())
+		}
+		if len(result.Cover.Cubes) != test.cubes {
+			t.Errorf("%s %s: %d cubes, want %d", test.backend, test.vector, len(result.Cover.Cubes), test.cubes)
+		}
+		if got := result.Cover.PrettyString(); got != test.cover {
+			t.Errorf("%s %s: cover %q, want %q", test.backend, test.vector, got, test.cover)
+		}
+	}
+}
+
+// Точный метод неопределенных коэффициентов находит покрытие той же
+// стоимости, что и метод Квайна-Мак-Класки, а КНФ стоит столько же,
+// сколько ДНФ инверсии
+func TestExactMatchesQMC(t *testing.T) {
+	costs := []logic.CostModel{logic.LiteralCost{}, logic.TermCost{}, logic.GateInputCost{}}
+	for n := 1; n <= 3; n++ {
+		for _, f := range functions(n, true) {
+			for _, cost := range costs {
+				want := minimize(t, "qmc", f, cost)
+				dnf := minimize(t, "nk", f, cost)
+				if dnf.Cost != want.Cost || !dnf.Optimal {
+					t.Errorf("nk %v (%s): cost %d, %s; qmc cost %d", f, cost.Name(), dnf.Cost, dnf.Certificate(), want.Cost)
+				}
+				if dnf.LowerBound > dnf.Cost {
+					t.Errorf("nk %v (%s): lower bound %d above cost %d", f, cost.Name(), dnf.LowerBound, dnf.Cost)
+				}
+				minimize(t, "nk-greedy", f, cost)
+			}
+			want := minimize(t, "qmc", complement(f), nil)
+			cnf := minimize(t, "nk-cnf", f, nil)
+			if cnf.Cost != want.Cost || !cnf.Optimal {
+				t.Errorf("nk-cnf %v: cost %d, %s; qmc of the complement cost %d", f, cnf.Cost, cnf.Certificate(), want.Cost)
+			}
+		}
+	}
+}
+
+// Некорректная спецификация - ошибка, а не паника, даже в обход logic.Minimize
+func TestMinimizeInvalidSpec(t *testing.T) {
+	specs := []struct {
+		spec   logic.Spec
+		target interface{}
+	}{
+		{logic.Spec{Values: nil}, new(*logic.LengthError)},
+		{logic.NewSpec([]int{0, 1, 1}), new(*logic.LengthError)},
+		{logic.NewSpec([]int{0, 1, 2, 1}), new(*logic.ValueError)},
+		{logic.Spec{Variables: 1, Values: []int{0, 1, 1, 1}}, new(*logic.ArityError)},
+	}
+	for _, backend := range []string{"nk", "nk-greedy", "nk-cnf"} {
+		m, err := logic.Lookup(backend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range specs {
+			_, err := m.Minimize(context.Background(), test.spec, logic.Options{})
+			if !errors.As(err, test.target) {
+				t.Errorf("%s %v: error %v, want %T", backend, test.spec, err, test.target)
+			}
+		}
+	}
+}
@@ -0,0 +1,608 @@
+package nk
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+type Var struct {
+	Number int
+	Value  bool
+}
+
+// Функция преобразования переменной в удобочитаемый вид
+func (v Var) PrettyString() string {
+	formatted := fmt.Sprintf("x%d", v.Number)
+	if !v.Value {
+		return "!" + formatted
+	}
+	return formatted
+}
+
+func (v Var) String() string {
+	return fmt.Sprintf("(%d,%t)", v.Number, v.Value)
+}
+
+// Структура представляет собой неопределенный коэффициент
+// Коэффициент хранится парой битовых масок: бит i маски Mask означает,
+// что переменная x_i входит в коэффициент, а бит i маски Values хранит
+// ее значение. Такой коэффициент можно сравнивать через == и использовать
+// как ключ в map без построения строкового представления
+type K struct {
+	Mask   uint32
+	Values uint32
+}
+
+// Функция возвращает переменные коэффициента в порядке возрастания номеров
+func (a K) Vars() []Var {
+	vars := make([]Var, 0, a.Rank())
+	for mask := a.Mask; mask != 0; mask &= mask - 1 {
+		number := bits.TrailingZeros32(mask)
+		vars = append(vars, Var{
+			Number: number,
+			Value:  a.Values&(1<<uint(number)) != 0,
+		})
+	}
+	return vars
+}
+
+// Функция возвращает ранг коэффициента - количество переменных в нем
+func (a K) Rank() int {
+	return bits.OnesCount32(a.Mask)
+}
+
+func (a K) PrettyString() string {
+	var prettyString string
+	for _, bit := range a.Vars() {
+		prettyString = bit.PrettyString() + prettyString
+	}
+	return prettyString
+}
+
+func (a K) KString() string {
+	vars := a.Vars()
+	var foramtted string
+	foramtted += "K_("
+	for _, v := range vars {
+		foramtted += strconv.Itoa(v.Number)
+	}
+	foramtted += ")^("
+	for _, v := range vars {
+		if v.Value {
+			foramtted += "1"
+		} else {
+			foramtted += "0"
+		}
+	}
+	foramtted += ")"
+	return foramtted
+}
+
+func (a K) String() string {
+	var stringed string
+	for _, v := range a.Vars() {
+		stringed += v.String()
+	}
+	return stringed
+}
+
+func (a K) Equal(b K) bool {
+	return a == b
+}
+
+func (a K) Less(b K) bool {
+	return a.Rank() < b.Rank()
+}
+
+func (a K) AppendVar(v Var) K {
+	bit := uint32(1) << uint(v.Number)
+	newK := K{
+		Mask:   a.Mask | bit,
+		Values: a.Values &^ bit,
+	}
+	if v.Value {
+		newK.Values |= bit
+	}
+	return newK
+}
+
+// Функция проверяет, что все переменные коэффициента a входят
+// в коэффициент b с теми же значениями
+func (a K) IsCovers(b K) bool {
+	return a.Mask&^b.Mask == 0 && b.Values&a.Mask == a.Values
+}
+
+// Функция проверяет, входит ли коэффициент в уравнение набора t
+func (a K) IsIn(t Term) bool {
+	return t.Values&a.Mask == a.Values
+}
+
+// Функция проверяет, входит ли коэффициент в уравнение набора с номером point
+// функции от n переменных
+func (a K) Contains(point, n int) bool {
+	return a.IsIn(NewTerm(point, n))
+}
+
+// Функция преобразует коэффициент в общий куб: кодировка масок у них совпадает
+func (a K) Cube() logic.Cube {
+	return logic.Cube{
+		Mask:   a.Mask,
+		Values: a.Values,
+	}
+}
+
+// Набор значений переменных: бит i маски Values хранит значение x_i,
+// а Size - количество переменных функции
+type Term struct {
+	Values uint32
+	Size   int
+}
+
+// Функция создает набор по номеру строки таблицы истинности
+// Переменная x0 соответствует старшему разряду номера
+func NewTerm(index, size int) Term {
+	t := Term{Size: size}
+	for i := 0; i < size; i++ {
+		if index&(1<<uint(size-1-i)) != 0 {
+			t.Values |= 1 << uint(i)
+		}
+	}
+	return t
+}
+
+// Функция возвращает маску всех переменных набора
+func (t Term) FullMask() uint32 {
+	return uint32(1)<<uint(t.Size) - 1
+}
+
+// Функция возвращает коэффициент набора по маске переменных
+func (t Term) Coefficient(mask uint32) K {
+	return K{
+		Mask:   mask,
+		Values: t.Values & mask,
+	}
+}
+
+// Функция печатает набор так же, как номер строки в двоичном виде: 000011
+func (t Term) String() string {
+	var formatted string
+	for i := 0; i < t.Size; i++ {
+		if t.Values&(1<<uint(i)) != 0 {
+			formatted += "1"
+		} else {
+			formatted += "0"
+		}
+	}
+	return formatted
+}
+
+// Функция перечисляет все непустые подмножества переменных набора
+// в порядке возрастания маски
+func (t Term) MakeAllSubsets() []K {
+	full := t.FullMask()
+	allSubsets := make([]K, 0, full)
+	for mask := uint32(1); mask <= full && mask != 0; mask++ {
+		allSubsets = append(allSubsets, t.Coefficient(mask))
+	}
+	return allSubsets
+}
+
+type Equation struct {
+	Term         Term
+	Coefficients []K
+	Value        bool
+	// ФАЛ не определена на наборе уравнения, Value не имеет смысла
+	Undefined bool
+}
+
+func (e Equation) KString() string {
+	var formatted string
+	for i, k := range e.Coefficients {
+		if i != 0 {
+			formatted += " + "
+		}
+		formatted += k.KString()
+	}
+	formatted += " = "
+	if e.Undefined {
+		formatted += "-"
+	} else if e.Value {
+		formatted += "1"
+	} else {
+		formatted += "0"
+	}
+	return formatted
+}
+
+// Функция возвращает множество коэффициентов уравнения
+func (e Equation) Set() map[K]struct{} {
+	set := make(map[K]struct{}, len(e.Coefficients))
+	for _, k := range e.Coefficients {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+func (e Equation) Include(q Equation) bool {
+	set := e.Set()
+	for _, qk := range q.Coefficients {
+		if _, found := set[qk]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Equation) ExcludeCoefficient(k K) {
+	newCoefficients := make([]K, 0, len(e.Coefficients))
+	for _, old := range e.Coefficients {
+		if old != k {
+			newCoefficients = append(newCoefficients, old)
+		}
+	}
+	e.Coefficients = newCoefficients
+}
+
+// Значение ФАЛ на наборе, где она не определена
+const DontCare = logic.DontCare
+
+// Функция разбирает значение ФАЛ на наборе
+// Второе значение сообщает, определена ли ФАЛ на наборе
+// Вектор значений заранее проверяется logic.Arity, поэтому
+// кроме 0 и 1 на наборе может встретиться только DontCare
+func parseValue(value int) (bool, bool) {
+	switch value {
+	case 0:
+		return false, true
+	case 1:
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// Функция возвращает полную систему уравнений для ФАЛ
+// Каждое уравнение содержит все 2^n - 1 коэффициентов своего набора
+func MakeSystemOfEquations(f []int) ([]Equation, error) {
+	variableNumber, err := logic.Arity(f)
+	if err != nil {
+		return nil, err
+	}
+
+	equations := make([]Equation, 0, len(f))
+	for i := range f {
+		term := NewTerm(i, variableNumber)
+		value, defined := parseValue(f[i])
+		equations = append(equations, Equation{
+			Term:         term,
+			Coefficients: term.MakeAllSubsets(),
+			Value:        value,
+			Undefined:    !defined,
+		})
+	}
+	return equations, nil
+}
+
+// Функция собирает множество коэффициентов, входящих в уравнения
+// наборов, на которых ФАЛ принимает значение value
+func coefficientsOf(f []int, variableNumber int, value bool) map[K]struct{} {
+	coefficients := make(map[K]struct{})
+	for i := range f {
+		if v, defined := parseValue(f[i]); !defined || v != value {
+			continue
+		}
+		term := NewTerm(i, variableNumber)
+		full := term.FullMask()
+		for mask := uint32(1); mask <= full && mask != 0; mask++ {
+			coefficients[term.Coefficient(mask)] = struct{}{}
+		}
+	}
+	return coefficients
+}
+
+// Функция строит систему уравнений сразу без нулевых коэффициентов
+// В отличие от MakeSystemOfEquations и ExcludeZeroCoefficients коэффициенты,
+// обнуленные нулевыми строками, отбрасываются еще до построения уравнений:
+// если коэффициент обнулен, то обнулены и все его подкоэффициенты, поэтому
+// маски перебираются от полной к пустой и вложенные в обнуленные пропускаются
+func MakeSystemWithoutZeros(f []int) ([]Equation, error) {
+	return makeSystemWithout(f, false)
+}
+
+// Функция строит систему уравнений для наборов, на которых ФАЛ не равна
+// excluded, исключая коэффициенты наборов, на которых она равна excluded
+// Неопределенные наборы не исключают коэффициенты и не требуют решения,
+// поэтому уравнения для них не строятся
+func makeSystemWithout(f []int, excluded bool) ([]Equation, error) {
+	variableNumber, err := logic.Arity(f)
+	if err != nil {
+		return nil, err
+	}
+	killers := coefficientsOf(f, variableNumber, excluded)
+
+	var equations []Equation
+	for i := range f {
+		if v, defined := parseValue(f[i]); !defined || v == excluded {
+			continue
+		}
+		term := NewTerm(i, variableNumber)
+		full := term.FullMask()
+		// Отметки об обнуленных масках данного набора
+		// Надмножества маски больше ее самой, поэтому к моменту проверки
+		// маски все ее надмножества уже проверены
+		killed := make([]bool, full+1)
+		coefficients := make([]K, 0)
+		for mask := full; mask != 0; mask-- {
+			for free := full &^ mask; free != 0; free &= free - 1 {
+				if killed[mask|free&-free] {
+					killed[mask] = true
+					break
+				}
+			}
+			if killed[mask] {
+				continue
+			}
+			coefficient := term.Coefficient(mask)
+			if _, found := killers[coefficient]; found {
+				killed[mask] = true
+				continue
+			}
+			coefficients = append(coefficients, coefficient)
+		}
+		// Сохраняем порядок возрастания масок, как в MakeAllSubsets
+		for l, r := 0, len(coefficients)-1; l < r; l, r = l+1, r-1 {
+			coefficients[l], coefficients[r] = coefficients[r], coefficients[l]
+		}
+		equations = append(equations, Equation{
+			Term:         term,
+			Coefficients: coefficients,
+			Value:        !excluded,
+		})
+	}
+	return equations, nil
+}
+
+func ExcludeZeroCoefficients(system []Equation) []Equation {
+	return excludeCoefficients(system, false)
+}
+
+// Функция оставляет в системе уравнения со значением !excluded и исключает
+// из них коэффициенты, входящие в уравнения со значением excluded
+// Уравнения неопределенных наборов отбрасываются и ничего не исключают
+func excludeCoefficients(system []Equation, excluded bool) []Equation {
+	killers := make(map[K]struct{})
+	for _, killerEquation := range system {
+		if !killerEquation.Undefined && killerEquation.Value == excluded {
+			for _, killerCoefficient := range killerEquation.Coefficients {
+				killers[killerCoefficient] = struct{}{}
+			}
+		}
+	}
+
+	newSystem := make([]Equation, 0, len(system))
+	for _, equationToExclude := range system {
+		if !equationToExclude.Undefined && equationToExclude.Value != excluded {
+			newEquation := Equation{
+				Term:  equationToExclude.Term,
+				Value: equationToExclude.Value,
+			}
+			for _, k := range equationToExclude.Coefficients {
+				if _, found := killers[k]; !found {
+					newEquation.Coefficients = append(newEquation.Coefficients, k)
+				}
+			}
+			newSystem = append(newSystem, newEquation)
+		}
+	}
+	return newSystem
+}
+
+type KS []K
+
+func (ks KS) Complexity() int {
+	complexity := 0
+	for _, r := range ks {
+		complexity += r.Rank()
+	}
+	return complexity
+}
+
+// Функция возвращает самые частые коэффициенты системы, а среди них -
+// самые дешевые в модели cost и самые короткие, и системы, решенные
+// каждым из них
+func GetMostRepeated(system []Equation, cost Cost) ([]K, [][]Equation) {
+	type repetition struct {
+		Count int
+		K
+	}
+
+	set := make(map[K]repetition)
+	for _, equation := range system {
+		for _, coefficient := range equation.Coefficients {
+			if r, found := set[coefficient]; found {
+				r.Count++
+				set[coefficient] = r
+			} else {
+				set[coefficient] = repetition{
+					Count: 1,
+					K:     coefficient,
+				}
+			}
+		}
+	}
+	maxRepeat := 0
+	for _, rep := range set {
+		if rep.Count > maxRepeat {
+			maxRepeat = rep.Count
+		}
+	}
+
+	// Среди самых частых выбираются самые дешевые, а из равных по
+	// стоимости - самые короткие: в модели TermCost все коэффициенты
+	// стоят одинаково, и без ранга перебирались бы почти все
+	minCost, minRank := math.MaxInt32, math.MaxInt32
+	for _, rep := range set {
+		if rep.Count != maxRepeat {
+			continue
+		}
+		if c, rank := cost.Of(rep.K), rep.K.Rank(); c < minCost || c == minCost && rank < minRank {
+			minCost, minRank = c, rank
+		}
+	}
+
+	var withMaxRepeats []K
+	for _, rep := range set {
+		if rep.Count == maxRepeat && cost.Of(rep.K) == minCost && rep.K.Rank() == minRank {
+			withMaxRepeats = append(withMaxRepeats, rep.K)
+		}
+	}
+	// Порядок обхода map случаен, а от порядка кандидатов зависит результат,
+	// поэтому упорядочиваем их, чтобы вывод программы был воспроизводимым
+	sort.Slice(withMaxRepeats, func(i, j int) bool {
+		a, b := withMaxRepeats[i], withMaxRepeats[j]
+		if a.Mask != b.Mask {
+			return a.Mask < b.Mask
+		}
+		return a.Values < b.Values
+	})
+
+	var newSystems [][]Equation
+	for _, repeat := range withMaxRepeats {
+		var newSystem []Equation
+		for _, equation := range system {
+			var isSolved = false
+			for _, coefficient := range equation.Coefficients {
+				if coefficient.Equal(repeat) {
+					isSolved = true
+					break
+				}
+			}
+			if !isSolved {
+				newSystem = append(newSystem, equation)
+			}
+		}
+		newSystems = append(newSystems, newSystem)
+	}
+	return withMaxRepeats, newSystems
+}
+
+// Функция жадно решает систему, добавляя к result самые частые коэффициенты
+// При нескольких равноценных кандидатах перебираются все, и выбирается
+// решение минимальной стоимости cost
+// Когда tracker останавливает перебор, берется только первый кандидат,
+// поэтому решение все равно достраивается до конца
+// Первое ветвление по равноценным кандидатам выполняется на потоках
+// tracker.Workers(), более глубокие - последовательно
+func GetMinimalVariant(system []Equation, result []K, cost Cost, tracker *logic.Tracker) []K {
+	return getMinimalVariant(system, result, cost, tracker, tracker.Workers())
+}
+
+func getMinimalVariant(system []Equation, result []K, cost Cost, tracker *logic.Tracker, workers int) []K {
+	if len(system) == 0 {
+		return result
+	}
+	for {
+		mostRepeateds, newSystems := GetMostRepeated(system, cost)
+		if !tracker.Step() {
+			mostRepeateds, newSystems = mostRepeateds[:1], newSystems[:1]
+		}
+		if len(mostRepeateds) == 1 {
+			mostRepeated, newSystem := mostRepeateds[0], newSystems[0]
+
+			result = append(result, mostRepeated)
+			system = newSystem
+
+			combSet := make(map[K]struct{})
+			for _, k := range result {
+				combSet[k] = struct{}{}
+			}
+
+			var isSystemSolved = true
+			for _, eq := range system {
+				var isEquationSolved = false
+				for _, eqK := range eq.Coefficients {
+					if _, found := combSet[eqK]; found {
+						isEquationSolved = true
+						break
+					}
+				}
+				if !isEquationSolved {
+					isSystemSolved = false
+					break
+				}
+			}
+			if isSystemSolved {
+				return result
+			}
+		} else {
+			possibleResults := make([][]K, len(mostRepeateds))
+			logic.RunBranches(len(mostRepeateds), workers, func(i int) {
+				possibleResults[i] = getMinimalVariant(newSystems[i], append(result[:len(result):len(result)], mostRepeateds[i]), cost, tracker, 1)
+			})
+
+			minComplexity := math.MaxInt32
+			var withMinComplexity []K
+			for i := range possibleResults {
+				complexity := cost.Total(possibleResults[i])
+				if complexity < minComplexity {
+					minComplexity = complexity
+					withMinComplexity = possibleResults[i]
+					tracker.Found(complexity)
+				}
+			}
+			return withMinComplexity
+		}
+	}
+}
+
+func ExcludeOther(system []Equation, included []K) []Equation {
+	includedSet := make(map[K]struct{}, len(included))
+	for _, k := range included {
+		includedSet[k] = struct{}{}
+	}
+
+	var newSystem []Equation
+	for _, equation := range system {
+		newEquation := Equation{
+			Term:      equation.Term,
+			Value:     equation.Value,
+			Undefined: equation.Undefined,
+		}
+		// Пустой коэффициент (константа) входит в каждое уравнение,
+		// хотя в системе его нет
+		if _, found := includedSet[K{}]; found {
+			newEquation.Coefficients = append(newEquation.Coefficients, K{})
+		}
+		for _, k := range equation.Coefficients {
+			if _, found := includedSet[k]; found {
+				newEquation.Coefficients = append(newEquation.Coefficients, k)
+			}
+		}
+		newSystem = append(newSystem, newEquation)
+	}
+	return newSystem
+}
+
+// Функция форматирует коэффициенты в ДНФ: пустая дизъюнкция - константа 0,
+// пустой коэффициент - константа 1
+func Format(ks []K) string {
+	if len(ks) == 0 {
+		return "0"
+	}
+	var result string
+	for i, k := range ks {
+		if i != 0 {
+			result += " + "
+		}
+		if k.Rank() == 0 {
+			result += "1"
+		}
+		result += k.PrettyString()
+	}
+	return result
+}
+
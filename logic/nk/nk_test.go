@@ -0,0 +1,121 @@
+package nk
+
+import (
+	"strings"
+	"testing"
+)
+
+// Функция перебирает все полностью определенные ФАЛ от n переменных
+func forEachVector(n int, visit func(f []int)) {
+	size := 1 << uint(n)
+	for i := 0; i < 1<<uint(size); i++ {
+		f := make([]int, size)
+		for point := range f {
+			f[point] = i >> uint(size-1-point) & 1
+		}
+		visit(f)
+	}
+}
+
+// Функция записывает систему построчно для сравнения в тестах
+func systemString(system []Equation) string {
+	lines := make([]string, 0, len(system))
+	for _, equation := range system {
+		lines = append(lines, equation.Term.String()+": "+equation.KString())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestNewTerm(t *testing.T) {
+	tests := []struct {
+		index, size int
+		values      uint32
+		formatted   string
+	}{
+		{0, 1, 0, "0"},
+		{1, 1, 1, "1"},
+		{1, 2, 2, "01"},
+		{2, 2, 1, "10"},
+		{6, 3, 3, "110"},
+		{5, 4, 10, "0101"},
+	}
+	for _, test := range tests {
+		term := NewTerm(test.index, test.size)
+		if term.Values != test.values || term.String() != test.formatted {
+			t.Errorf("NewTerm(%d, %d) = %b %q, want %b %q", test.index, test.size, term.Values, term, test.values, test.formatted)
+		}
+	}
+}
+
+func TestK(t *testing.T) {
+	tests := []struct {
+		k              K
+		rank           int
+		kString, terms string
+	}{
+		{K{Mask: 1, Values: 1}, 1, "K_(0)^(1)", "x0"},
+		{K{Mask: 5, Values: 1}, 2, "K_(02)^(10)", "!x2x0"},
+		{K{Mask: 7, Values: 6}, 3, "K_(012)^(011)", "x2x1!x0"},
+	}
+	for _, test := range tests {
+		if test.k.Rank() != test.rank {
+			t.Errorf("%s: rank %d, want %d", test.kString, test.k.Rank(), test.rank)
+		}
+		if test.k.KString() != test.kString {
+			t.Errorf("KString %q, want %q", test.k.KString(), test.kString)
+		}
+		if test.k.PrettyString() != test.terms {
+			t.Errorf("%s: PrettyString %q, want %q", test.kString, test.k.PrettyString(), test.terms)
+		}
+	}
+
+	x0 := K{Mask: 1, Values: 1}
+	x0x1 := x0.AppendVar(Var{Number: 1, Value: false})
+	if x0x1 != (K{Mask: 3, Values: 1}) {
+		t.Errorf("AppendVar: %s", x0x1.KString())
+	}
+	if !x0.IsCovers(x0x1) || x0x1.IsCovers(x0) {
+		t.Error("x0 must cover x0!x1 and not vice versa")
+	}
+	if (K{Mask: 1}).IsCovers(x0x1) {
+		t.Error("!x0 must not cover x0!x1")
+	}
+	// Набор 100 (x0 = 1) входит в уравнения x0 и x0!x1, но не !x0
+	if !x0x1.Contains(4, 3) || (K{Mask: 1}).Contains(4, 3) {
+		t.Error("wrong coefficients of point 4")
+	}
+}
+
+// Система, построенная без нулевых коэффициентов, совпадает с полной
+// системой после ExcludeZeroCoefficients на всех ФАЛ до 3 переменных
+func TestMakeSystemWithoutZeros(t *testing.T) {
+	for n := 1; n <= 3; n++ {
+		forEachVector(n, func(f []int) {
+			full, err := MakeSystemOfEquations(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(full) != len(f) || len(full[0].Coefficients) != 1<<uint(n)-1 {
+				t.Fatalf("%v: full system of %d equations", f, len(full))
+			}
+			lazy, err := MakeSystemWithoutZeros(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := systemString(lazy), systemString(ExcludeZeroCoefficients(full)); got != want {
+				t.Errorf("%v: system\n%s\nwant\n%s", f, got, want)
+			}
+		})
+	}
+}
+
+func TestMakeSystemErrors(t *testing.T) {
+	for _, f := range [][]int{nil, {0, 1, 1}, {0, 2}} {
+		if _, err := MakeSystemWithoutZeros(f); err == nil {
+			t.Errorf("MakeSystemWithoutZeros(%v) succeeded", f)
+		}
+		if _, err := MakeSystemOfEquations(f); err == nil {
+			t.Errorf("MakeSystemOfEquations(%v) succeeded", f)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package nk
+
+import (
+	"fmt"
+	"io"
+)
+
+// Функция исключает поглощенные уравнения
+// Если коэффициенты уравнения e содержат все коэффициенты уравнения q,
+// то любое решение q решает и e, поэтому e можно не рассматривать
+// Из одинаковых уравнений остается первое
+// Возвращает новую систему и исключенные уравнения
+func ExcludeAbsorbedEquations(system []Equation) ([]Equation, []Equation) {
+	var newSystem, absorbed []Equation
+	for i, e := range system {
+		var isAbsorbed = false
+		for j, q := range system {
+			if i == j || len(q.Coefficients) > len(e.Coefficients) || !e.Include(q) {
+				continue
+			}
+			// Равные уравнения поглощают друг друга, оставляем первое из них
+			if len(q.Coefficients) == len(e.Coefficients) && j > i {
+				continue
+			}
+			isAbsorbed = true
+			break
+		}
+		if isAbsorbed {
+			absorbed = append(absorbed, e)
+		} else {
+			newSystem = append(newSystem, e)
+		}
+	}
+	return newSystem, absorbed
+}
+
+// Пара коэффициентов: Dominated исключается в пользу более короткого Dominant
+type Domination struct {
+	Dominant  K
+	Dominated K
+}
+
+// Функция сравнивает коэффициенты для доминирования: по стоимости в модели
+// cost, затем по рангу, затем по маскам, чтобы из двух коэффициентов,
+// решающих одни и те же уравнения, исключался ровно один
+func (c Cost) less(a, b K) bool {
+	if ca, cb := c.Of(a), c.Of(b); ca != cb {
+		return ca < cb
+	}
+	if a.Rank() != b.Rank() {
+		return a.Rank() < b.Rank()
+	}
+	if a.Mask != b.Mask {
+		return a.Mask < b.Mask
+	}
+	return a.Values < b.Values
+}
+
+// Функция исключает доминируемые коэффициенты
+// Коэффициент b доминируется не более дорогим в модели cost коэффициентом a,
+// если a решает как минимум те же уравнения, что и b: тогда замена b на a
+// в любом решении не увеличивает стоимость; при равной стоимости
+// доминирует меньший в порядке Cost.less, поэтому в модели TermCost
+// коэффициенты тоже исключаются
+// Для неаддитивных моделей (TransistorCost) по вкладам коэффициентов
+// доминирование не доказать, поэтому коэффициенты не исключаются
+// Возвращает новую систему и исключенные пары
+func ExcludeDominatedCoefficients(system []Equation, cost Cost) ([]Equation, []Domination) {
+	if !cost.Model.Additive() {
+		return system, nil
+	}
+	// Для каждого коэффициента запоминаем номера уравнений, которые он решает
+	var order []K
+	solves := make(map[K][]uint64)
+	words := (len(system) + 63) / 64
+	for i, equation := range system {
+		for _, k := range equation.Coefficients {
+			if _, found := solves[k]; !found {
+				solves[k] = make([]uint64, words)
+				order = append(order, k)
+			}
+			solves[k][i/64] |= 1 << uint(i%64)
+		}
+	}
+	isSubset := func(a, b []uint64) bool {
+		for i := range a {
+			if a[i]&^b[i] != 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	var dominations []Domination
+	dominated := make(map[K]struct{})
+	for _, b := range order {
+		for _, a := range order {
+			if !cost.less(a, b) {
+				continue
+			}
+			if _, found := dominated[a]; found {
+				continue
+			}
+			// Подкоэффициент решает все уравнения своего надкоэффициента,
+			// в остальных случаях сравниваем множества решаемых уравнений
+			if a.IsCovers(b) || isSubset(solves[b], solves[a]) {
+				dominated[b] = struct{}{}
+				dominations = append(dominations, Domination{
+					Dominant:  a,
+					Dominated: b,
+				})
+				break
+			}
+		}
+	}
+	if len(dominations) == 0 {
+		return system, nil
+	}
+
+	newSystem := make([]Equation, 0, len(system))
+	for _, equation := range system {
+		newEquation := equation
+		newEquation.Coefficients = nil
+		for _, k := range equation.Coefficients {
+			if _, found := dominated[k]; !found {
+				newEquation.Coefficients = append(newEquation.Coefficients, k)
+			}
+		}
+		newSystem = append(newSystem, newEquation)
+	}
+	return newSystem, dominations
+}
+
+// Функция упрощает систему без нулевых коэффициентов, поочередно исключая
+// поглощенные уравнения и доминируемые коэффициенты, пока система меняется
+// Каждый шаг записывается в log: исключенные уравнения и коэффициенты,
+// а затем получившаяся система построчно, в тех же обозначениях, что и
+// выгрузка этапов в текстовом формате
+func Reduce(system []Equation, cost Cost, log io.Writer) []Equation {
+	size := 0
+	if len(system) != 0 {
+		size = system[0].Term.Size
+	}
+	for step := 1; ; step++ {
+		var absorbed []Equation
+		system, absorbed = ExcludeAbsorbedEquations(system)
+		if len(absorbed) != 0 {
+			fmt.Fprintf(log, "step %d: absorbed equations (%d)\n", step, len(absorbed))
+			writeText(log, Stage{System: absorbed})
+		}
+
+		var dominations []Domination
+		system, dominations = ExcludeDominatedCoefficients(system, cost)
+		if len(dominations) != 0 {
+			fmt.Fprintf(log, "step %d: dominated coefficients (%d)\n", step, len(dominations))
+			for _, d := range dominations {
+				fmt.Fprintf(log, "%s by %s\n", paperString(d.Dominated, size), paperString(d.Dominant, size))
+			}
+		}
+
+		if len(absorbed) == 0 && len(dominations) == 0 {
+			fmt.Fprintf(log, "step %d: nothing to reduce\n", step)
+			return system
+		}
+		fmt.Fprintf(log, "step %d: system (%d)\n", step, len(system))
+		writeText(log, Stage{System: system})
+	}
+}
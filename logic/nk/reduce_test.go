@@ -0,0 +1,210 @@
+package nk
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+var costModels = []logic.CostModel{
+	logic.LiteralCost{},
+	logic.TermCost{},
+	logic.GateInputCost{},
+	logic.WeightedCost{Weights: []int{3, 1, 2}},
+}
+
+// Упрощение не меняет стоимость точного решения ни в одной модели
+func TestReducePreservesOptimum(t *testing.T) {
+	tracker := logic.NewTracker(context.Background(), logic.Options{})
+	logictest.ForEachFunction(3, true, func(f []int) {
+		for _, form := range []Form{DNF, CNF} {
+			if form.IsConstant(f) {
+				continue
+			}
+			system, err := MakeSystem(f, form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, model := range costModels {
+				cost := Cost{Model: model, Form: form}
+				full := cost.Total(GetExactVariant(system, cost, tracker))
+				reduced := Reduce(system, cost, ioutil.Discard)
+				result := GetExactVariant(reduced, cost, tracker)
+				if got := cost.Total(result); got != full {
+					t.Fatalf("%v %v (%s): reduced optimum %d, full %d", f, form, model.Name(), got, full)
+				}
+				if mismatches, _ := form.Verify(f, result); len(mismatches) != 0 {
+					t.Fatalf("%v %v (%s): %v", f, form, model.Name(), mismatches)
+				}
+			}
+		}
+	})
+}
+
+func TestExcludeAbsorbedEquations(t *testing.T) {
+	a, b, c := K{Mask: 1, Values: 1}, K{Mask: 2, Values: 2}, K{Mask: 3, Values: 3}
+	equation := func(index int, ks ...K) Equation {
+		return Equation{Term: NewTerm(index, 2), Coefficients: ks, Value: true}
+	}
+	tests := []struct {
+		name             string
+		system           []Equation
+		reduced, removed string
+	}{
+		{"superset", []Equation{equation(3, a, b), equation(2, a)}, "10: K_(0)^(1) = 1", "11: K_(0)^(1) + K_(1)^(1) = 1"},
+		{"equal keeps first", []Equation{equation(2, a), equation(3, a)}, "10: K_(0)^(1) = 1", "11: K_(0)^(1) = 1"},
+		{"independent", []Equation{equation(2, a), equation(1, b, c)}, "10: K_(0)^(1) = 1\n01: K_(1)^(1) + K_(01)^(11) = 1", ""},
+		{"empty", nil, "", ""},
+	}
+	for _, test := range tests {
+		reduced, removed := ExcludeAbsorbedEquations(test.system)
+		if got := systemString(reduced); got != test.reduced {
+			t.Errorf("%s: system\n%s\nwant\n%s", test.name, got, test.reduced)
+		}
+		if got := systemString(removed); got != test.removed {
+			t.Errorf("%s: absorbed\n%s\nwant\n%s", test.name, got, test.removed)
+		}
+	}
+}
+
+// Reduce повторяет шаги, пока система меняется: в результате нечего
+// поглощать и исключать, а последний шаг журнала сообщает об этом
+func TestReduceFixedPoint(t *testing.T) {
+	cost := Cost{Model: logic.LiteralCost{}, Form: DNF}
+	logictest.ForEachFunction(3, true, func(f []int) {
+		system, err := MakeSystem(f, DNF)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var log strings.Builder
+		reduced := Reduce(system, cost, &log)
+		if _, absorbed := ExcludeAbsorbedEquations(reduced); len(absorbed) != 0 {
+			t.Fatalf("%v: %d absorbed equations left", f, len(absorbed))
+		}
+		if _, dominations := ExcludeDominatedCoefficients(reduced, cost); len(dominations) != 0 {
+			t.Fatalf("%v: %d dominated coefficients left", f, len(dominations))
+		}
+		if !strings.HasSuffix(log.String(), ": nothing to reduce\n") {
+			t.Fatalf("%v: log\n%s", f, log.String())
+		}
+	})
+}
+
+func TestDominance(t *testing.T) {
+	tests := []struct {
+		vector string
+		model  logic.CostModel
+		// Число коэффициентов системы до и после исключения доминируемых
+		before, after int
+	}{
+		// f = x0 + x1: в каждом уравнении остается по одной переменной
+		{"0111", logic.LiteralCost{}, 5, 2},
+		{"0111", logic.TermCost{}, 5, 2},
+		{"01111111", logic.TermCost{}, 19, 3},
+		{"01111111", logic.LiteralCost{}, 19, 3},
+		// Неаддитивная модель не упрощается
+		{"0111", logic.TransistorCost{}, 5, 5},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		system, err := MakeSystem(spec.Values, DNF)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reduced, _ := ExcludeDominatedCoefficients(system, Cost{Model: test.model, Form: DNF})
+		if got := distinct(system); got != test.before {
+			t.Errorf("%s: %d coefficients before, want %d", test.vector, got, test.before)
+		}
+		if got := distinct(reduced); got != test.after {
+			t.Errorf("%s (%s): %d coefficients after, want %d", test.vector, test.model.Name(), got, test.after)
+		}
+	}
+}
+
+func distinct(system []Equation) int {
+	set := make(map[K]struct{})
+	for _, equation := range system {
+		for _, k := range equation.Coefficients {
+			set[k] = struct{}{}
+		}
+	}
+	return len(set)
+}
+
+// В модели TermCost все коэффициенты стоят одинаково, поэтому жадный
+// поиск по упрощенной системе, как в Minimizer, выбирает и исключает
+// коэффициенты по рангу, как в модели LiteralCost, и перебирает столько же
+func TestGreedyTermCost(t *testing.T) {
+	vectors := []string{
+		"11000000001010010011101101111101",
+		"1-10-0111-0-0-1--01-1-01110-1-00",
+		"0110100110010110",
+	}
+	for _, vector := range vectors {
+		spec, err := logic.ParseSpec(vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		system, err := MakeSystem(spec.Values, DNF)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var evaluated []int64
+		var candidates [][]K
+		for _, model := range []logic.CostModel{logic.TermCost{}, logic.LiteralCost{}} {
+			cost := Cost{Model: model, Form: DNF}
+			reduced := Reduce(system, cost, ioutil.Discard)
+			most, _ := GetMostRepeated(reduced, cost)
+			candidates = append(candidates, most)
+			tracker := logic.NewTracker(context.Background(), logic.Options{Budget: logic.Budget{Nodes: 100000}})
+			result := GetMinimalVariant(reduced, nil, cost, tracker)
+			if tracker.Err() != nil {
+				t.Errorf("%s (%s): greedy search stopped after %d nodes: %v", vector, model.Name(), tracker.Evaluated(), tracker.Err())
+			}
+			if mismatches, _ := DNF.Verify(spec.Values, result); len(mismatches) != 0 {
+				t.Errorf("%s (%s): %v", vector, model.Name(), mismatches)
+			}
+			evaluated = append(evaluated, tracker.Evaluated())
+		}
+		if evaluated[0] != evaluated[1] {
+			t.Errorf("%s: %d greedy nodes for terms, %d for literals", vector, evaluated[0], evaluated[1])
+		}
+		if Format(candidates[0]) != Format(candidates[1]) {
+			t.Errorf("%s: candidates %s for terms, %s for literals", vector, Format(candidates[0]), Format(candidates[1]))
+		}
+	}
+}
+
+// Журнал упрощения пишется в нумерации домашнего задания, как этапы метода
+func TestReduceLog(t *testing.T) {
+	system, err := MakeSystem([]int{0, 1, 1, 1}, DNF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log strings.Builder
+	Reduce(system, Cost{Model: logic.LiteralCost{}, Form: DNF}, &log)
+	want := `step 1: dominated coefficients (3)
+K_21^01 by K_1^1
+K_21^10 by K_2^1
+K_21^11 by K_1^1
+step 1: system (3)
+K_1^1 = f(01) = 1
+K_2^1 = f(10) = 1
+K_2^1 ∨ K_1^1 = f(11) = 1
+step 2: absorbed equations (1)
+K_2^1 ∨ K_1^1 = f(11) = 1
+step 2: system (2)
+K_1^1 = f(01) = 1
+K_2^1 = f(10) = 1
+step 3: nothing to reduce
+`
+	if log.String() != want {
+		t.Errorf("log\n%s\nwant\n%s", log.String(), want)
+	}
+}
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// Общий для параллельных ветвей перебора рекорд стоимости
+// Рекорд сравнивается по паре (стоимость, номер ветви): при равной
+// стоимости лучше ветвь с меньшим номером, то есть найденная раньше
+// при последовательном переборе ветвей по порядку, поэтому результат
+// не зависит от того, в каком порядке потоки находят решения
+type SharedBound struct {
+	packed int64
+}
+
+func packBound(cost, branch int) int64 {
+	return int64(cost)<<32 | int64(uint32(branch))
+}
+
+// Функция создает рекорд без найденных решений
+func NewSharedBound() *SharedBound {
+	return &SharedBound{packed: packBound(math.MaxInt32, math.MaxInt32)}
+}
+
+// Функция сообщает, что решение стоимости не меньше cost в ветви branch
+// не лучше рекорда, поэтому ветвь можно отсечь
+func (b *SharedBound) Prunes(cost, branch int) bool {
+	return packBound(cost, branch) >= atomic.LoadInt64(&b.packed)
+}
+
+// Функция обновляет рекорд решением стоимости cost из ветви branch
+// Возвращает true, если решение лучше рекорда
+func (b *SharedBound) Improve(cost, branch int) bool {
+	packed := packBound(cost, branch)
+	for {
+		current := atomic.LoadInt64(&b.packed)
+		if packed >= current {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&b.packed, current, packed) {
+			return true
+		}
+	}
+}
+
+// Функция возвращает стоимость рекорда и номер ветви, в которой он найден
+func (b *SharedBound) Best() (cost, branch int) {
+	packed := atomic.LoadInt64(&b.packed)
+	return int(packed >> 32), int(uint32(packed))
+}
+
+// Функция выполняет run для ветвей 0..branches-1 на workers потоках
+// Ветви раздаются по порядку номеров; при workers <= 1 они выполняются
+// последовательно в вызывающем потоке
+func RunBranches(branches, workers int, run func(branch int)) {
+	if workers <= 1 || branches <= 1 {
+		for branch := 0; branch < branches; branch++ {
+			run(branch)
+		}
+		return
+	}
+	if workers > branches {
+		workers = branches
+	}
+	next := int64(-1)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				branch := int(atomic.AddInt64(&next, 1))
+				if branch >= branches {
+					return
+				}
+				run(branch)
+			}
+		}()
+	}
+	wg.Wait()
+}
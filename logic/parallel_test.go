@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSharedBound(t *testing.T) {
+	b := NewSharedBound()
+	if b.Prunes(100, 0) {
+		t.Error("empty bound prunes")
+	}
+	steps := []struct {
+		cost, branch int
+		improves     bool
+	}{
+		{10, 5, true},
+		{10, 7, false},
+		// Равная стоимость в ветви с меньшим номером лучше
+		{10, 2, true},
+		{11, 0, false},
+		{9, 8, true},
+	}
+	for _, step := range steps {
+		if got := b.Improve(step.cost, step.branch); got != step.improves {
+			t.Errorf("Improve(%d, %d) = %t, want %t", step.cost, step.branch, got, step.improves)
+		}
+	}
+	if cost, branch := b.Best(); cost != 9 || branch != 8 {
+		t.Errorf("Best() = %d, %d, want 9, 8", cost, branch)
+	}
+	if !b.Prunes(9, 8) || !b.Prunes(9, 9) || b.Prunes(9, 7) || b.Prunes(8, 100) {
+		t.Error("wrong pruning around the record 9 in branch 8")
+	}
+}
+
+// Параллельные улучшения рекорда дают тот же результат, что и последовательные
+func TestSharedBoundConcurrent(t *testing.T) {
+	b := NewSharedBound()
+	RunBranches(64, 8, func(branch int) {
+		b.Improve(100-branch%10, branch)
+	})
+	if cost, branch := b.Best(); cost != 91 || branch != 9 {
+		t.Errorf("Best() = %d, %d, want 91, 9", cost, branch)
+	}
+}
+
+func TestRunBranches(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 16} {
+		for _, branches := range []int{0, 1, 5, 100} {
+			var mutex sync.Mutex
+			runs := make([]int, branches)
+			var total int64
+			RunBranches(branches, workers, func(branch int) {
+				mutex.Lock()
+				runs[branch]++
+				mutex.Unlock()
+				atomic.AddInt64(&total, 1)
+			})
+			if total != int64(branches) {
+				t.Errorf("%d branches on %d workers: %d runs", branches, workers, total)
+			}
+			for branch, count := range runs {
+				if count != 1 {
+					t.Errorf("%d workers: branch %d ran %d times", workers, branch, count)
+				}
+			}
+		}
+	}
+}
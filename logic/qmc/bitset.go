@@ -0,0 +1,59 @@
+package qmc
+
+import "math/bits"
+
+// Множество номеров в битах: бит j слова j/64 отвечает номеру j
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (s bitset) set(j int) {
+	s[j/64] |= 1 << uint(j%64)
+}
+
+func (s bitset) has(j int) bool {
+	return s[j/64]&(1<<uint(j%64)) != 0
+}
+
+// Функция возвращает объединение множеств, не меняя их
+func (s bitset) or(other bitset) bitset {
+	union := make(bitset, len(s))
+	for w := range s {
+		union[w] = s[w] | other[w]
+	}
+	return union
+}
+
+// Функция сообщает, содержит ли s все элементы other
+func (s bitset) containsAll(other bitset) bool {
+	for w := range s {
+		if other[w]&^s[w] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s bitset) count() int {
+	count := 0
+	for _, word := range s {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Функция возвращает строки таблицы как множества покрываемых ими столбцов
+func (t Table) rowSets() []bitset {
+	sets := make([]bitset, len(t.Rows))
+	for i := range t.Rows {
+		sets[i] = newBitset(len(t.Columns))
+		for j := range t.Columns {
+			if t.Marks[i][j] {
+				sets[i].set(j)
+			}
+		}
+	}
+	return sets
+}
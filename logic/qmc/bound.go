@@ -0,0 +1,61 @@
+package qmc
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"math"
+	"sort"
+)
+
+// Функция оценивает снизу стоимость любого покрытия таблицы
+// Жадно набираем попарно непересекающиеся столбцы, то есть такие, что
+// ни одна строка не покрывает два из них: каждому нужна собственная строка,
+// поэтому сумма минимальных вкладов строк этих столбцов не превосходит
+// стоимости любого покрытия
+// Возвращает оценку и номера выбранных столбцов
+func (t Table) LowerBound(cost logic.CostModel) (int, []int) {
+	rowsOf := make([][]int, len(t.Columns))
+	for j := range t.Columns {
+		for i := range t.Rows {
+			if t.Marks[i][j] {
+				rowsOf[j] = append(rowsOf[j], i)
+			}
+		}
+	}
+	order := make([]int, len(t.Columns))
+	for j := range order {
+		order[j] = j
+	}
+	// Столбцы с меньшим числом отметок сильнее ограничивают покрытие
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(rowsOf[order[a]]) < len(rowsOf[order[b]])
+	})
+
+	usedRows := make([]bool, len(t.Rows))
+	bound := 0
+	var columns []int
+	for _, j := range order {
+		if len(rowsOf[j]) == 0 {
+			continue
+		}
+		var isDisjoint = true
+		for _, i := range rowsOf[j] {
+			if usedRows[i] {
+				isDisjoint = false
+				break
+			}
+		}
+		if !isDisjoint {
+			continue
+		}
+		minCost := math.MaxInt32
+		for _, i := range rowsOf[j] {
+			usedRows[i] = true
+			if c := cost.CubeCost(logic.DNF, t.Rows[i].Term.Cube()); c < minCost {
+				minCost = c
+			}
+		}
+		bound += minCost
+		columns = append(columns, j)
+	}
+	return bound, columns
+}
@@ -0,0 +1,69 @@
+package qmc_test
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"testing"
+)
+
+// Нижняя граница не превосходит стоимости минимального покрытия,
+// а выбранные для нее столбцы попарно не покрываются общей строкой
+func TestLowerBound(t *testing.T) {
+	for _, model := range costModels {
+		logictest.ForEachFunction(3, true, func(f []int) {
+			steps, err := qmc.Prepare(logic.NewSpec(f))
+			if err != nil {
+				t.Fatal(err)
+			}
+			bound, columns := steps.Table.LowerBound(model)
+			result := minimize(t, "qmc", f, model)
+			if bound > result.Cost || result.LowerBound > result.Cost {
+				t.Fatalf("%v (%s): bounds %d and %d exceed cost %d", f, model.Name(), bound, result.LowerBound, result.Cost)
+			}
+			if result.LowerBound < bound {
+				t.Errorf("%v (%s): result bound %d below table bound %d", f, model.Name(), result.LowerBound, bound)
+			}
+			for a := range columns {
+				for b := a + 1; b < len(columns); b++ {
+					for i := range steps.Table.Rows {
+						if steps.Table.Marks[i][columns[a]] && steps.Table.Marks[i][columns[b]] {
+							t.Fatalf("%v: row %d covers columns %d and %d", f, i, columns[a], columns[b])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLowerBoundTable(t *testing.T) {
+	tests := []struct {
+		vector  string
+		model   logic.CostModel
+		bound   int
+		columns int
+	}{
+		// Существенные x1 и x0: границы достаточно для доказательства
+		{"0111", logic.LiteralCost{}, 2, 2},
+		{"0111", logic.GateInputCost{}, 4, 2},
+		// Циклическая ФАЛ: три попарно непересекающихся столбца из шести
+		{"01111110", logic.LiteralCost{}, 6, 3},
+		{"01111110", logic.TermCost{}, 3, 3},
+		{"0000", logic.LiteralCost{}, 0, 0},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		steps, err := qmc.Prepare(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bound, columns := steps.Table.LowerBound(test.model)
+		if bound != test.bound || len(columns) != test.columns {
+			t.Errorf("%s (%s): bound %d on %d columns, want %d on %d", test.vector, test.model.Name(), bound, len(columns), test.bound, test.columns)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package qmc
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"strings"
+)
+
+// Ограничения библиотеки вентилей на двухуровневую реализацию покрытия
+// Нулевое значение ограничения означает, что оно не задано
+type Constraints struct {
+	// Наибольшее число литералов в конъюнкции - число входов вентиля И
+	MaxLiterals int
+	// Наибольшее число конъюнкций - число входов вентиля ИЛИ
+	MaxProducts int
+	// Штраф за каждую переменную, входящую в покрытие с инверсией
+	ComplementPenalty int
+}
+
+// Функция сообщает, задано ли хотя бы одно ограничение
+func (c Constraints) IsSet() bool {
+	return c.MaxLiterals != 0 || c.MaxProducts != 0 || c.ComplementPenalty != 0
+}
+
+// Функция добавляет к модели стоимости штраф за инверсии
+func (c Constraints) Cost(base logic.CostModel) logic.CostModel {
+	if c.ComplementPenalty == 0 {
+		return base
+	}
+	return logic.ComplementPenalty{Base: base, Penalty: c.ComplementPenalty}
+}
+
+// Функция сообщает, помещается ли импликанта в вентиль И
+func (c Constraints) Allows(term Term) bool {
+	return c.MaxLiterals == 0 || term.Literals() <= c.MaxLiterals
+}
+
+func (c Constraints) String() string {
+	limit := func(value int) string {
+		if value == 0 {
+			return "unlimited"
+		}
+		return fmt.Sprint(value)
+	}
+	return fmt.Sprintf("max literals per product %s, max products %s, complement penalty %d",
+		limit(c.MaxLiterals), limit(c.MaxProducts), c.ComplementPenalty)
+}
+
+// Функция возвращает количество литералов импликанты
+func (a Term) Literals() int {
+	count := 0
+	for _, bit := range a {
+		if bit != Tilde {
+			count++
+		}
+	}
+	return count
+}
+
+// Функция возвращает номер набора, которому соответствует импликанта СДНФ
+func (a Term) Point() int {
+	point := 0
+	for _, bit := range a {
+		point <<= 1
+		if bit == True {
+			point |= 1
+		}
+	}
+	return point
+}
+
+// Результат минимизации с ограничениями
+type ConstrainedCover struct {
+	Terms []Term
+	Cost  int
+	// Покрытие реализуется двухуровневой схемой без нарушения ограничений
+	Feasible bool
+	// Исходные наборы, которые покрываются только конъюнкциями
+	// шире MaxLiterals
+	Uncoverable []Term
+	// Количество конъюнкций шире MaxLiterals в покрытии
+	WideProducts int
+}
+
+// Ключ сравнения покрытий: сначала число широких конъюнкций, затем
+// превышение MaxProducts, затем стоимость
+// Добавление строки не уменьшает ни одну из компонент, поэтому ключ
+// частичного покрытия с суммой вкладов кубов вместо стоимости - нижняя
+// граница ключа любого его продолжения
+type constrainedKey [3]int
+
+func (a constrainedKey) less(b constrainedKey) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// Состояние точного поиска покрытия с ограничениями
+type constrainedSearch struct {
+	t           Table
+	constraints Constraints
+	cost        logic.CostModel
+	// Номера строк, покрывающих каждый столбец
+	coveringRows [][]int
+	tracker      *logic.Tracker
+	best         []int
+	bestKey      constrainedKey
+	found        bool
+}
+
+func (s *constrainedSearch) key(rows []int, cubeCost int) constrainedKey {
+	wide := 0
+	for _, i := range rows {
+		if !s.constraints.Allows(s.t.Rows[i].Term) {
+			wide++
+		}
+	}
+	excess := 0
+	if s.constraints.MaxProducts != 0 && len(rows) > s.constraints.MaxProducts {
+		excess = len(rows) - s.constraints.MaxProducts
+	}
+	return constrainedKey{wide, excess, cubeCost}
+}
+
+func (s *constrainedSearch) terms(rows []int) []Term {
+	terms := make([]Term, 0, len(rows))
+	for _, i := range rows {
+		terms = append(terms, s.t.Rows[i].Term)
+	}
+	return terms
+}
+
+func (s *constrainedSearch) search(covered []bool, rows []int, cubeCost int) {
+	if !s.tracker.Step() {
+		return
+	}
+	if s.found && !s.key(rows, cubeCost).less(s.bestKey) {
+		return
+	}
+	column := -1
+	for j := range covered {
+		if !covered[j] {
+			column = j
+			break
+		}
+	}
+	if column == -1 {
+		key := s.key(rows, Cost(s.cost, s.terms(rows)))
+		if !s.found || key.less(s.bestKey) {
+			s.best = append([]int(nil), rows...)
+			s.bestKey = key
+			s.found = true
+			s.tracker.Found(key[2])
+		}
+		return
+	}
+	// Любое покрытие содержит одну из строк, покрывающих первый
+	// непокрытый столбец, поэтому достаточно перебрать только их
+	for _, i := range s.coveringRows[column] {
+		next := make([]bool, len(covered))
+		for j := range covered {
+			next[j] = covered[j] || s.t.Marks[i][j]
+		}
+		cube := s.cost.CubeCost(logic.DNF, s.t.Rows[i].Term.Cube())
+		s.search(next, append(rows[:len(rows):len(rows)], i), cubeCost+cube)
+	}
+}
+
+// Функция ищет самое дешевое в модели cost покрытие таблицы t, которое
+// удовлетворяет ограничениям c
+// Если таких покрытий нет, возвращается ближайшее: с наименьшим числом
+// конъюнкций шире MaxLiterals, затем с наименьшим превышением MaxProducts
+// Поиск точный, поэтому годится для таблиц, которые строит kmk
+// Если tracker останавливает перебор, возвращается лучшее найденное
+// покрытие, а если его нет - покрытие из всех строк таблицы
+func MinimizeConstrained(t Table, c Constraints, cost logic.CostModel, tracker *logic.Tracker) ConstrainedCover {
+	tracker.SetStage("constrained cover search")
+	s := &constrainedSearch{
+		t:            t,
+		constraints:  c,
+		cost:         c.Cost(cost),
+		coveringRows: make([][]int, len(t.Columns)),
+		tracker:      tracker,
+	}
+	var result ConstrainedCover
+	for j := range t.Columns {
+		allowed := false
+		for i := range t.Rows {
+			if t.Marks[i][j] {
+				s.coveringRows[j] = append(s.coveringRows[j], i)
+				allowed = allowed || c.Allows(t.Rows[i].Term)
+			}
+		}
+		if !allowed {
+			result.Uncoverable = append(result.Uncoverable, t.Columns[j].Term)
+		}
+	}
+	s.search(make([]bool, len(t.Columns)), nil, 0)
+	if !s.found {
+		for i := range t.Rows {
+			s.best = append(s.best, i)
+		}
+		s.bestKey = s.key(s.best, Cost(s.cost, s.terms(s.best)))
+	}
+
+	result.Terms = s.terms(s.best)
+	result.Cost = s.bestKey[2]
+	result.WideProducts = s.bestKey[0]
+	result.Feasible = s.bestKey[0] == 0 && s.bestKey[1] == 0
+	return result
+}
+
+// Вентиль схемы
+type Gate struct {
+	Name   string
+	Op     string
+	Inputs []string
+}
+
+func (g Gate) String() string {
+	return fmt.Sprintf("%s = %s(%s)", g.Name, g.Op, strings.Join(g.Inputs, ", "))
+}
+
+// Схема из вентилей И/ИЛИ с ограниченным числом входов
+// Входами служат литералы, инверсии переменных считаются отдельно
+type Netlist struct {
+	Gates     []Gate
+	Output    string
+	Levels    int
+	Inverters int
+	depth     map[string]int
+}
+
+func (n *Netlist) gate(op string, inputs []string) string {
+	name := fmt.Sprintf("g%d", len(n.Gates)+1)
+	n.Gates = append(n.Gates, Gate{Name: name, Op: op, Inputs: inputs})
+	depth := 0
+	for _, input := range inputs {
+		if n.depth[input] > depth {
+			depth = n.depth[input]
+		}
+	}
+	n.depth[name] = depth + 1
+	if depth+1 > n.Levels {
+		n.Levels = depth + 1
+	}
+	return name
+}
+
+// Функция строит дерево вентилей op с не более чем fanIn входами
+// fanIn = 0 означает, что число входов не ограничено
+func (n *Netlist) tree(op string, inputs []string, fanIn int) string {
+	if fanIn < 2 {
+		fanIn = len(inputs)
+	}
+	for len(inputs) > fanIn {
+		var next []string
+		for i := 0; i < len(inputs); i += fanIn {
+			end := i + fanIn
+			if end > len(inputs) {
+				end = len(inputs)
+			}
+			if end-i == 1 {
+				next = append(next, inputs[i])
+				continue
+			}
+			next = append(next, n.gate(op, inputs[i:end]))
+		}
+		inputs = next
+	}
+	if len(inputs) == 1 {
+		return inputs[0]
+	}
+	return n.gate(op, inputs)
+}
+
+// Функция реализует ДНФ вентилями с ограниченным числом входов:
+// широкие конъюнкции и дизъюнкция разбиваются на деревья вентилей,
+// поэтому схема может получиться не двухуровневой
+func MakeNetlist(terms []Term, c Constraints) Netlist {
+	n := Netlist{depth: make(map[string]int)}
+	complemented := make(map[int]struct{})
+	var products []string
+	for _, term := range terms {
+		var literals []string
+		for i := len(term) - 1; i >= 0; i-- {
+			if term[i] == False {
+				complemented[i] = struct{}{}
+			}
+			if literal := term[i].PrettyString(i); literal != "" {
+				literals = append(literals, literal)
+			}
+		}
+		if len(literals) == 0 {
+			literals = []string{"1"}
+		}
+		products = append(products, n.tree("AND", literals, c.MaxLiterals))
+	}
+	if len(products) == 0 {
+		products = []string{"0"}
+	}
+	n.Output = n.tree("OR", products, c.MaxProducts)
+	n.Inverters = len(complemented)
+	return n
+}
+
+func (n Netlist) String() string {
+	var formatted string
+	for _, gate := range n.Gates {
+		formatted += gate.String() + "\n"
+	}
+	formatted += "f = " + n.Output + "\n"
+	return formatted
+}
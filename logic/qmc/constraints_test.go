@@ -0,0 +1,143 @@
+package qmc_test
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"testing"
+)
+
+// Функция перебором наборов простых импликант находит ключ лучшего покрытия
+// с ограничениями: число широких конъюнкций, превышение MaxProducts, стоимость
+func bruteForceConstrained(steps qmc.Steps, c qmc.Constraints, model logic.CostModel) [3]int {
+	best := [3]int{-1}
+	less := func(a, b [3]int) bool {
+		for i := range a {
+			if a[i] != b[i] {
+				return a[i] < b[i]
+			}
+		}
+		return false
+	}
+	for subset := 0; subset < 1<<uint(len(steps.Prime)); subset++ {
+		var terms []qmc.Term
+		for i, term := range steps.Prime {
+			if subset&(1<<uint(i)) != 0 {
+				terms = append(terms, term)
+			}
+		}
+		covers := true
+		for _, column := range steps.Source {
+			var covered = false
+			for _, term := range terms {
+				covered = covered || term.Covers(column)
+			}
+			covers = covers && covered
+		}
+		if !covers {
+			continue
+		}
+		var key [3]int
+		for _, term := range terms {
+			if !c.Allows(term) {
+				key[0]++
+			}
+		}
+		if c.MaxProducts != 0 && len(terms) > c.MaxProducts {
+			key[1] = len(terms) - c.MaxProducts
+		}
+		key[2] = qmc.Cost(c.Cost(model), terms)
+		if best[0] == -1 || less(key, best) {
+			best = key
+		}
+	}
+	return best
+}
+
+// Поиск с ограничениями точен на всех ФАЛ от 3 переменных и на ФАЛ
+// от 2 переменных с неопределенными наборами
+func TestMinimizeConstrained(t *testing.T) {
+	constraints := []qmc.Constraints{
+		{},
+		{MaxLiterals: 2},
+		{MaxLiterals: 1},
+		{MaxProducts: 2},
+		{MaxLiterals: 2, MaxProducts: 1},
+		{ComplementPenalty: 3},
+	}
+	check := func(f []int) {
+		spec := logic.NewSpec(f)
+		steps, err := qmc.Prepare(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps.Source) == 0 {
+			return
+		}
+		for _, c := range constraints {
+			tracker := logic.NewTracker(context.Background(), logic.Options{})
+			result := qmc.MinimizeConstrained(steps.Table, c, logic.LiteralCost{}, tracker)
+			cover := qmc.MakeCover(spec.Variables, result.Terms)
+			if mismatches, _ := cover.Verify(f); len(mismatches) != 0 {
+				t.Fatalf("%v (%s): %s: %v", f, c, cover.PrettyString(), mismatches)
+			}
+			want := bruteForceConstrained(steps, c, logic.LiteralCost{})
+			if result.WideProducts != want[0] || result.Cost != want[2] || result.Feasible != (want[0] == 0 && want[1] == 0) {
+				t.Errorf("%v (%s): %s, cost %d, %d wide, feasible %t; want cost %d, %d wide, excess %d",
+					f, c, cover.PrettyString(), result.Cost, result.WideProducts, result.Feasible, want[2], want[0], want[1])
+			}
+			if c.MaxLiterals != 0 && len(result.Uncoverable) != 0 && result.Feasible {
+				t.Errorf("%v (%s): feasible with uncoverable points %s", f, c, qmc.String(result.Uncoverable))
+			}
+		}
+	}
+	logictest.ForEachFunction(3, false, check)
+	logictest.ForEachFunction(2, true, check)
+}
+
+// Без ограничений поиск находит покрытие той же стоимости, что и qmc
+func TestMinimizeConstrainedUnset(t *testing.T) {
+	logictest.ForEachFunction(3, true, func(f []int) {
+		spec := logic.NewSpec(f)
+		steps, err := qmc.Prepare(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps.Source) == 0 {
+			return
+		}
+		tracker := logic.NewTracker(context.Background(), logic.Options{})
+		result := qmc.MinimizeConstrained(steps.Table, qmc.Constraints{}, logic.GateInputCost{}, tracker)
+		if want := minimize(t, "qmc", f, logic.GateInputCost{}); result.Cost != want.Cost || !result.Feasible {
+			t.Errorf("%v: constrained cost %d, qmc %d", f, result.Cost, want.Cost)
+		}
+	})
+}
+
+func TestMakeNetlist(t *testing.T) {
+	x2x1x0 := qmc.Term{qmc.True, qmc.True, qmc.True}
+	notX0 := qmc.Term{qmc.False, qmc.Tilde, qmc.Tilde}
+	tests := []struct {
+		terms     []qmc.Term
+		c         qmc.Constraints
+		netlist   string
+		levels    int
+		inverters int
+	}{
+		{[]qmc.Term{x2x1x0, notX0}, qmc.Constraints{}, "g1 = AND(x2, x1, x0)\ng2 = OR(g1, !x0)\nf = g2\n", 2, 1},
+		{[]qmc.Term{x2x1x0, notX0}, qmc.Constraints{MaxLiterals: 2},
+			"g1 = AND(x2, x1)\ng2 = AND(g1, x0)\ng3 = OR(g2, !x0)\nf = g3\n", 3, 1},
+		{[]qmc.Term{x2x1x0, notX0, {qmc.Tilde, qmc.False, qmc.Tilde}}, qmc.Constraints{MaxProducts: 2},
+			"g1 = AND(x2, x1, x0)\ng2 = OR(g1, !x0)\ng3 = OR(g2, !x1)\nf = g3\n", 3, 2},
+		{[]qmc.Term{notX0}, qmc.Constraints{}, "f = !x0\n", 0, 1},
+		{[]qmc.Term{{qmc.Tilde}}, qmc.Constraints{}, "f = 1\n", 0, 0},
+		{nil, qmc.Constraints{}, "f = 0\n", 0, 0},
+	}
+	for _, test := range tests {
+		netlist := qmc.MakeNetlist(test.terms, test.c)
+		if netlist.String() != test.netlist || netlist.Levels != test.levels || netlist.Inverters != test.inverters {
+			t.Errorf("%s (%s): %d levels, %d inverters\n%s", qmc.Format(test.terms), test.c, netlist.Levels, netlist.Inverters, netlist)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package qmc
+
+import (
+	"context"
+	"errors"
+	"github.com/AndreevSemen/asvt/logic"
+	"testing"
+)
+
+func TestTermArity(t *testing.T) {
+	a := Term{True, False}
+	b := Term{True, False, Tilde}
+	var arityErr *logic.ArityError
+	if _, err := a.Distance(b); !errors.As(err, &arityErr) || arityErr.Expected != 2 || arityErr.Actual != 3 {
+		t.Errorf("Distance: %v", err)
+	}
+	if _, err := a.DifferentBitIndex(b); !errors.As(err, &arityErr) {
+		t.Errorf("DifferentBitIndex: %v", err)
+	}
+	// Импликанты 10 и 11~ попадают в соседние весовые группы и сравниваются
+	if _, err := Step1([]Term{a, {True, True, Tilde}}); !errors.As(err, &arityErr) {
+		t.Errorf("Step1: %v", err)
+	}
+
+	tests := []struct {
+		a, b     Term
+		distance int
+		index    int
+	}{
+		{Term{True, False}, Term{True, False}, 0, -1},
+		{Term{True, False}, Term{True, True}, 1, 1},
+		{Term{Tilde, False, True}, Term{True, False, False}, 2, 0},
+	}
+	for _, test := range tests {
+		distance, err := test.a.Distance(test.b)
+		if err != nil || distance != test.distance {
+			t.Errorf("%s.Distance(%s) = %d, %v, want %d", test.a, test.b, distance, err, test.distance)
+		}
+		index, err := test.a.DifferentBitIndex(test.b)
+		if err != nil || index != test.index {
+			t.Errorf("%s.DifferentBitIndex(%s) = %d, %v, want %d", test.a, test.b, index, err, test.index)
+		}
+	}
+}
+
+// Некорректная спецификация - ошибка, а не паника, даже в обход logic.Minimize
+func TestMinimizeInvalidSpec(t *testing.T) {
+	specs := []struct {
+		spec   logic.Spec
+		target interface{}
+	}{
+		{logic.Spec{Values: nil}, new(*logic.LengthError)},
+		{logic.NewSpec([]int{0, 1, 1}), new(*logic.LengthError)},
+		{logic.NewSpec([]int{0, 1, 2, 1}), new(*logic.ValueError)},
+		{logic.Spec{Variables: 1, Values: []int{0, 1, 1, 1}}, new(*logic.ArityError)},
+	}
+	for _, m := range []Minimizer{{}, {SAT: true}} {
+		for _, test := range specs {
+			_, err := m.Minimize(context.Background(), test.spec, logic.Options{})
+			if !errors.As(err, test.target) {
+				t.Errorf("%s %v: error %v, want %T", m.Name(), test.spec, err, test.target)
+			}
+		}
+	}
+	if _, err := MakeSDNF([]int{0, 1, -2, 1}); err == nil {
+		t.Error("MakeSDNF accepted value -2")
+	}
+}
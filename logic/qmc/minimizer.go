@@ -0,0 +1,157 @@
+package qmc
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"time"
+)
+
+// Метод Квайна-Мак-Класки как алгоритм минимизации
+// Таблица покрытия решается перебором Step5 либо, если SAT, с помощью
+// SAT-решателя (MinimizeSAT)
+type Minimizer struct {
+	SAT bool
+}
+
+func init() {
+	logic.Register(Minimizer{})
+	logic.Register(Minimizer{SAT: true})
+}
+
+// Имена: qmc - перебор Step5, qmc-sat - SAT-решатель
+func (m Minimizer) Name() string {
+	if m.SAT {
+		return "qmc-sat"
+	}
+	return "qmc"
+}
+
+// Функция преобразует импликанту в общий куб
+func (a Term) Cube() logic.Cube {
+	var cube logic.Cube
+	for i, bit := range a {
+		if bit == Tilde {
+			continue
+		}
+		cube.Mask |= 1 << uint(i)
+		if bit == True {
+			cube.Values |= 1 << uint(i)
+		}
+	}
+	return cube
+}
+
+// Функция преобразует импликанты в общее покрытие
+func MakeCover(variables int, terms []Term) logic.Cover {
+	cover := logic.Cover{
+		Variables: variables,
+		Form:      logic.DNF,
+		Cubes:     make([]logic.Cube, 0, len(terms)),
+	}
+	for _, term := range terms {
+		cover.Cubes = append(cover.Cubes, term.Cube())
+	}
+	return cover
+}
+
+// Шаги 1-4 метода для ФАЛ с неопределенными наборами
+type Steps struct {
+	// СДНФ единичных наборов - столбцы таблицы покрытия
+	Source []Term
+	// Неопределенные наборы, которые участвуют только в склейке
+	DontCares []Term
+	// Простые импликанты, покрывающие хотя бы один единичный набор
+	Prime []Term
+	// Таблица покрытия после шагов 2-4 и номера ее существенных строк
+	Table     Table
+	Essential map[int]struct{}
+}
+
+// Функция выполняет шаги 1-4 метода: неопределенные наборы участвуют
+// в склейке на первом шаге, но не входят в столбцы таблицы покрытия
+func Prepare(spec logic.Spec) (Steps, error) {
+	if err := spec.Validate(); err != nil {
+		return Steps{}, err
+	}
+	ones := make([]int, len(spec.Values))
+	dontCares := make([]int, len(spec.Values))
+	onesAndDontCares := make([]int, len(spec.Values))
+	for point, value := range spec.Values {
+		if value == 1 {
+			ones[point] = 1
+		}
+		if value == logic.DontCare {
+			dontCares[point] = 1
+		}
+		if value == 1 || value == logic.DontCare {
+			onesAndDontCares[point] = 1
+		}
+	}
+	var steps Steps
+	var err error
+	if steps.Source, err = MakeSDNF(ones); err != nil {
+		return Steps{}, err
+	}
+	if steps.DontCares, err = MakeSDNF(dontCares); err != nil {
+		return Steps{}, err
+	}
+	withDontCares, err := MakeSDNF(onesAndDontCares)
+	if err != nil {
+		return Steps{}, err
+	}
+	glued, err := Step1(withDontCares)
+	if err != nil {
+		return Steps{}, err
+	}
+	// Простые импликанты, покрывающие только неопределенные наборы, не нужны
+	for _, term := range glued {
+		for _, column := range steps.Source {
+			if term.Covers(column) {
+				steps.Prime = append(steps.Prime, term)
+				break
+			}
+		}
+	}
+	steps.Table, steps.Essential = Steps2and3and4(steps.Prime, steps.Source)
+	return steps, nil
+}
+
+func (m Minimizer) Minimize(ctx context.Context, spec logic.Spec, options logic.Options) (logic.Result, error) {
+	start := time.Now()
+	steps, err := Prepare(spec)
+	if err != nil {
+		return logic.Result{}, err
+	}
+	table, essential := steps.Table, steps.Essential
+	cost := options.CostModel()
+	tracker := logic.NewTracker(ctx, options)
+	var result []Term
+	var proven bool
+	if m.SAT {
+		result, proven, err = MinimizeSAT(table, essential, cost, tracker)
+		if err != nil {
+			return logic.Result{}, err
+		}
+	} else {
+		// Шаг 5 перебирает все комбинации строк таблицы,
+		// если его не остановили
+		result = Step5(table, essential, cost, tracker)
+		proven = tracker.Err() == nil
+	}
+	bound, _ := table.LowerBound(cost)
+
+	cover := MakeCover(spec.Variables, result)
+	total := cover.Cost(cost)
+	return logic.Result{
+		Cover:      cover,
+		Cost:       total,
+		LowerBound: bound,
+		Optimal:    proven || total == bound,
+		Stats: logic.Stats{
+			Duration:   time.Since(start),
+			Candidates: len(steps.Prime),
+			Evaluated:  tracker.Evaluated(),
+			Stopped:    tracker.Err(),
+		},
+	}, logic.CanceledError(tracker.Err())
+}
@@ -0,0 +1,100 @@
+package qmc_test
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"testing"
+)
+
+var costModels = []logic.CostModel{
+	logic.LiteralCost{},
+	logic.TermCost{},
+	logic.GateInputCost{},
+	logic.TransistorCost{},
+	logic.WeightedCost{Weights: []int{3, 1, 2}},
+}
+
+func minimize(t *testing.T, name string, f []int, model logic.CostModel) logic.Result {
+	t.Helper()
+	result, err := logic.Minimize(context.Background(), name, logic.NewSpec(f), logic.Options{Cost: model})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mismatches, err := result.Cover.Verify(f); err != nil || len(mismatches) != 0 {
+		t.Fatalf("%s %v: %s: %v %v", name, f, result.Cover.PrettyString(), mismatches, err)
+	}
+	if result.Cost != result.Cover.Cost(model) {
+		t.Fatalf("%s %v: cost %d, cover costs %d", name, f, result.Cost, result.Cover.Cost(model))
+	}
+	return result
+}
+
+// Стоимость совпадает с перебором всех покрытий на ФАЛ до 2 переменных
+func TestMinimizeBruteForce(t *testing.T) {
+	for n := 1; n <= 2; n++ {
+		logictest.ForEachFunction(n, true, func(f []int) {
+			for _, model := range costModels {
+				want := logictest.BruteForceCost(f, n, logic.DNF, model)
+				for _, name := range []string{"qmc", "qmc-sat"} {
+					if name == "qmc-sat" && !model.Additive() {
+						continue
+					}
+					result := minimize(t, name, f, model)
+					if result.Cost != want || !result.Optimal {
+						t.Errorf("%s %v (%s): cost %d (optimal %t), want %d", name, f, model.Name(), result.Cost, result.Optimal, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Все ФАЛ от 3 переменных: покрытие верно, минимальность доказана,
+// а SAT-решатель находит покрытие той же стоимости
+func TestMinimizeExhaustive(t *testing.T) {
+	for _, model := range []logic.CostModel{logic.LiteralCost{}, logic.GateInputCost{}} {
+		logictest.ForEachFunction(3, false, func(f []int) {
+			result := minimize(t, "qmc", f, model)
+			if !result.Optimal || result.LowerBound > result.Cost {
+				t.Errorf("%v (%s): %s", f, model.Name(), result.Certificate())
+			}
+			if sat := minimize(t, "qmc-sat", f, model); sat.Cost != result.Cost {
+				t.Errorf("%v (%s): qmc-sat cost %d, qmc %d", f, model.Name(), sat.Cost, result.Cost)
+			}
+		})
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	tests := []struct {
+		vector            string
+		prime             string
+		source, essential int
+	}{
+		{"0111", "x1 + x0", 3, 2},
+		{"0-11", "x1 + x0", 2, 1},
+		{"01111110", "", 6, 0},
+	}
+	for _, test := range tests {
+		spec, err := logic.ParseSpec(test.vector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		steps, err := qmc.Prepare(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.prime != "" && qmc.Format(steps.Prime) != test.prime {
+			t.Errorf("%s: prime implicants %s, want %s", test.vector, qmc.Format(steps.Prime), test.prime)
+		}
+		if len(steps.Source) != test.source || len(steps.Essential) != test.essential {
+			t.Errorf("%s: %d ones and %d essential rows, want %d and %d",
+				test.vector, len(steps.Source), len(steps.Essential), test.source, test.essential)
+		}
+	}
+	if _, err := qmc.Prepare(logic.NewSpec([]int{0, 1, 1})); err == nil {
+		t.Error("Prepare accepted a vector of length 3")
+	}
+}
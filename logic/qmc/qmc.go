@@ -0,0 +1,543 @@
+package qmc
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Представляет одну переменную в импликанте
+type Bit int
+
+// Переменная может:
+const (
+	Tilde Bit = -1 // Отсутствовать в импиканте
+	False Bit = 0 // Быть инвертированной
+	True  Bit = 1 // Быть прямой
+)
+
+// Функция преобразования переменной в удобочитаемый вид
+func (b Bit) PrettyString(index int) string {
+	switch b {
+	case Tilde:
+		return ""
+	case False:
+		return "!x" + strconv.Itoa(index)
+	case True:
+		return "x" + strconv.Itoa(index)
+	default:
+		return fmt.Sprintf("Bit(%d)", b)
+	}
+}
+
+func (b Bit) String() string {
+	switch b {
+	case Tilde:
+		return "~"
+	case False:
+		return "0"
+	case True:
+		return "1"
+	default:
+		return fmt.Sprintf("Bit(%d)", b)
+	}
+}
+
+// Представляет любую импликанту (терм) в виде набора переменных
+type Term []Bit
+
+// Функция преобразования импликанты в удобочитаемый вид
+// Переводим каждую переменную в строку и конкатенируем их
+// Стоит отметить, что переменные импликанты печатаются в обратном порядке:
+// 01~~ -> "x1!x0"
+// Импликанта без литералов (~~~~) - константа 1
+func (a Term) PrettyString() string {
+	var prettyString string
+	for i, bit := range a {
+		prettyString = bit.PrettyString(i) + prettyString
+	}
+	if prettyString == "" {
+		return "1"
+	}
+	return prettyString
+}
+
+func (a Term) String() string {
+	var prettyString string
+	for _, bit := range a {
+		prettyString = bit.String() + prettyString
+	}
+	return prettyString
+}
+
+// Функция сравнения импликант на равенство
+func (a Term) Equals(b Term) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция нахождения расстояния между двумя импликантами
+func (a Term) Distance(b Term) (int, error) {
+	// Если импликанты зависят от разного количества
+	// переменных, то считаем, что они не сравнимы
+	if len(a) != len(b) {
+		return 0, &logic.ArityError{Expected: len(a), Actual: len(b)}
+	}
+	dist := 0
+	for i := range a {
+		if a[i] != b[i] {
+			dist++
+		}
+	}
+	return dist, nil
+}
+
+// Функция расчета веса импликанты
+func (a Term) Weight() int {
+	count := 0
+	for _, bit := range a {
+		if bit == True {
+			count++
+		}
+	}
+	return count
+}
+
+
+// Функция которая возвращает номер первой переменной,
+// в которой импликанты различаются
+func (a Term) DifferentBitIndex(b Term) (int, error) {
+	// Если импликанты зависят от разного количества
+	// переменных, то считаем, что они не сравнимы
+	if len(a) != len(b) {
+		return 0, &logic.ArityError{Expected: len(a), Actual: len(b)}
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// Функция проверки вхождения одной импликанты в другую
+// К примеру импликанты 10~0 и 1~10 входят в импликанту 1~~0
+func (a Term) Covers(b Term) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != Tilde {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Функция проверяет, входит ли в импликанту набор с номером point
+// функции от n переменных; x0 соответствует старшему разряду номера
+func (a Term) Contains(point, n int) bool {
+	if len(a) != n {
+		return false
+	}
+	for i, bit := range a {
+		value := True
+		if point&(1<<uint(n-1-i)) == 0 {
+			value = False
+		}
+		if bit != Tilde && bit != value {
+			return false
+		}
+	}
+	return true
+}
+
+// На первом шаге алгоритма мы разбиваем импликанты на группы по весу
+// Данная структура представляет собой импликанту с отметкой о том,
+// была ли она задействована при образовании новой склеенной импликанты
+type GroupItem struct {
+	Term
+	IsGlued bool
+}
+
+// Вспомогательная функция для создания элемента по умолчанию
+func NewGroupItem(term Term) GroupItem {
+	return GroupItem{
+		Term:    term,
+		IsGlued: false,
+	}
+}
+
+// Представляет собой отображение веса на группу элементов с данным весом
+type Groups map[int][]GroupItem
+
+// Функция, которая создает группы, объединенные по весам
+func GroupByWeight(terms []Term) Groups {
+	if len(terms) == 0 {
+		return nil
+	}
+	groups := make(map[int][]GroupItem, len(terms[0]))
+	for _, term := range terms {
+		// Считаем вес импликанты и добавляем ее в соответствующую группу
+		weight := term.Weight()
+		groups[weight] = append(groups[weight], NewGroupItem(term))
+	}
+	return groups
+}
+
+// Функция возвращает веса групп по возрастанию
+// Порядок обхода map случаен, а от порядка импликант зависит выбор
+// среди равных по стоимости покрытий на шаге 5
+func (g Groups) Weights() []int {
+	weights := make([]int, 0, len(g))
+	for weight := range g {
+		weights = append(weights, weight)
+	}
+	sort.Ints(weights)
+	return weights
+}
+
+// Функцию реализует процесс склейки соседних по весу групп
+// Результатом функции является набор импликант, образовавшихся при склеивании
+func GlueGroups(a, b []GroupItem) (newTerms []Term, err error) {
+	// Перебираем все возможные пары элементов двух групп
+	for i := range a {
+		for j := range b {
+			distance, err := a[i].Distance(b[j].Term)
+			if err != nil {
+				return nil, err
+			}
+			// Если он отличаются в одной позиции, то производим склейку
+			if distance == 1 {
+				// Импликанты, которые участвовали в склеивании помечаются
+				// поднятым флагом IsGlued для того, чтобы далее их можно было исключить
+				a[i].IsGlued = true
+				b[j].IsGlued = true
+				// Создаем новую импликанту
+				newTerm := make(Term, len(a[i].Term))
+				copy(newTerm, a[i].Term)
+				index, err := a[i].DifferentBitIndex(b[j].Term)
+				if err != nil {
+					return nil, err
+				}
+				newTerm[index] = Tilde
+				newTerms = append(newTerms, newTerm)
+			}
+		}
+	}
+	return newTerms, nil
+}
+
+// Функция создает новый набор на основе входного, но без повторяющихся элементов
+// К пр.: 10~1, 10~1, 010~, ~1~~ -> 10~1, 010~, ~1~~
+func MakeUniqueSet(terms []Term) []Term {
+	// Множество уникальных элементов
+	uniqueSet := make([]Term, 0)
+	for _, term := range terms {
+		// Ищем элемент term во множестве уже найденных уникальных элементов
+		found := false
+		for _, termInSet := range uniqueSet {
+			if termInSet.Equals(term) {
+				found = true
+				break
+			}
+		}
+		// Если элемент еще не присутствует в уникальных, то добавляем его
+		if !found {
+			uniqueSet = append(uniqueSet, term)
+		}
+	}
+	return uniqueSet
+}
+
+// Функцию реализует первый шаг алгоритма - склейка импликант
+// Функция возвращает набор импликант, которые больше невозможно склеить
+// Импликанты разной длины - ошибка logic.ArityError
+func Step1(impls []Term) ([]Term, error) {
+	// Формируем весовые группы
+	groups := GroupByWeight(impls)
+	// Склеиваем каждую весовую группу с предыдущей по весу, если такая имеется
+	// Склеенные импликанты сохраняем
+	glued := make([]Term, 0)
+	for _, weight := range groups.Weights() {
+		groupB := groups[weight]
+		if groupA, found := groups[weight-1]; found {
+			newGlued, err := GlueGroups(groupA, groupB)
+			if err != nil {
+				return nil, err
+			}
+			glued = append(glued, newGlued...)
+		}
+	}
+	// Если не произошло ни одного склеивания, то возвращаем входной набор импликант
+	if len(glued) == 0 {
+		return impls, nil
+	}
+	// Ищем те импликанты, которые не были склеены
+	unaffectedTerms := make([]Term, 0)
+	for _, weight := range groups.Weights() {
+		for _, term := range groups[weight] {
+			if !term.IsGlued {
+				unaffectedTerms = append(unaffectedTerms, term.Term)
+			}
+		}
+	}
+	// К новым полученным импликантам добавляем те, что не были склеены
+	impls = MakeUniqueSet(append(unaffectedTerms, glued...))
+	// Запускаем следующий шаг рекурсии
+	return Step1(impls)
+}
+
+// Линия представляет собой описание строки либо столбца таблицы для шагов 2, 3 и 4
+// Каждой линии соответствует импликанта и служебная метка
+type Line struct {
+	Term Term
+	IsMarked bool
+}
+
+// Таблица для шагов 2, 3 и 4
+// Содержит описания столбцов и строк, а так же таблицу меток о том, что
+// импликанта строки покрывает импликанту столбца
+type Table struct {
+	Columns []Line
+	Rows []Line
+	Marks [][]bool
+}
+
+// Создает новую таблицу из наборов простых и исходных импликант
+func NewTable(prime, source []Term) Table {
+	t := Table{}
+	// Каждой строке ставим соответствие простую импликанту
+	for _, row := range prime {
+		t.Rows = append(t.Rows, Line{
+			Term:     row,
+			IsMarked: false,
+		})
+	}
+	// Каждому столбцу ставим в соответствие исходную импликанту
+	for _, column := range source {
+		t.Columns = append(t.Columns, Line{
+			Term:     column,
+			IsMarked: false,
+		})
+	}
+	// Заполняем таблицу меток
+	t.Marks = make([][]bool, len(t.Rows))
+	for i := range t.Rows {
+		t.Marks[i] = make([]bool, len(t.Columns))
+		for j := range t.Columns {
+			// Если строка покрывает столбец, то ставим отметку
+			if t.Rows[i].Term.Covers(t.Columns[j].Term) {
+				t.Marks[i][j] = true
+			}
+		}
+	}
+	return t
+}
+
+func (t Table) PrettyString() string {
+	const cellSize = 6
+	var formatted string
+	formatted += "|" + strings.Repeat(" ", cellSize) + "|"
+	for _, column := range t.Columns {
+		formatted += fmt.Sprintf("%" + strconv.Itoa(cellSize) + "s|", column.Term.String())
+	}
+	rowLen := len(formatted)
+	formatLine := func(i, rowLen int) string {
+		var formatted string
+		underline := "\n" + strings.Repeat("-", rowLen) + "\n"
+		if i == 0 {
+			formatted += underline
+		}
+
+		formatted += fmt.Sprintf("|%" + strconv.Itoa(cellSize) + "s|", t.Rows[i].Term.String())
+		for j := range t.Columns {
+			var mark string
+			if t.Marks[i][j] {
+				mark = strings.Repeat("X", cellSize)
+			} else {
+				mark = "     "
+			}
+			formatted += fmt.Sprintf("%" + strconv.Itoa(cellSize) + "s|", mark)
+		}
+		formatted += underline
+		return formatted
+	}
+	for i := range t.Rows {
+		formatted += formatLine(i, rowLen)
+	}
+	return formatted
+}
+
+// Данная функция проверяет покрывает ли набор строк под номерами
+// из rows все импликанты
+func (t Table) IsRowsCovers(rows map[int]struct{}) bool {
+	// Создаем массив меток о том, что столбцы были покрыты
+	coveredColumns := make([]bool, len(t.Columns))
+	// Проверяем каждую строку
+	for i := range t.Rows {
+		// Если номер строки не содержится в rowsTakeOff, то переходим к следующей строке
+		if _, found := rows[i]; !found {
+			continue
+		}
+		// Ставим метки о том, какие столбцы были покрыты
+		for j := range t.Columns {
+			if t.Marks[i][j] == true {
+				coveredColumns[j] = true
+			}
+		}
+	}
+	// Если хоть один из столбцов не был покрыт, то набор оставшихся
+	// строк не покрывает функцию полностью
+	for _, covered := range coveredColumns {
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// Реализует 2, 3 и 4 шаги алгоритма
+// Возвращает таблицу и набор существенных строк
+func Steps2and3and4(prime, source []Term) (Table, map[int]struct{}) {
+	// Создаем таблицу
+	t := NewTable(prime, source)
+	// Ищем существенные строки и столбцы
+	for j := range t.Columns {
+		marksInColumn := 0
+		rowWithMark := 0
+		// Считаем количество отметок в столбце
+		for i := range t.Rows {
+			if t.Marks[i][j] {
+				marksInColumn++
+				rowWithMark = i
+			}
+		}
+		// Если в столбце только одна отметка, то строка и столбец,
+		// соответствующие этой отметке существенны
+		if marksInColumn == 1 {
+			t.Columns[j].IsMarked = true
+			t.Rows[rowWithMark].IsMarked = true
+		}
+	}
+	// Составляем набор существенных строк
+	essentials := make(map[int]struct{}, 0)
+	for i := range t.Rows {
+		if t.Rows[i].IsMarked {
+			essentials[i] = struct{}{}
+		}
+	}
+	return t, essentials
+}
+
+// Функция реализует 5 шаг алгоритма
+// Из покрывающих комбинаций выбирается самая дешевая в модели cost
+// Перебор отсекает только комбинации, которые не могут быть дешевле
+// найденной, поэтому результат минимален среди покрытий простыми
+// импликантами, а значит, и среди всех покрытий
+// Если tracker останавливает перебор, возвращается лучшее найденное
+// покрытие, а если его нет - покрытие из всех строк таблицы
+func Step5(t Table, essential map[int]struct{}, cost logic.CostModel, tracker *logic.Tracker) []Term {
+	// Если существенные строки уже покрывают таблицу, то другие не нужны
+	if t.IsRowsCovers(essential) {
+		var result []Term
+		for index := range t.Rows {
+			if _, found := essential[index]; found {
+				result = append(result, t.Rows[index].Term)
+			}
+		}
+		return result
+	}
+	// Перебираем комбинации из k несущественных строк, добавляя к ним
+	// существенные, вплоть до комбинации из всех строк таблицы
+	search := newCoverSearch(t, essential, cost, tracker)
+	search.run()
+	if !search.found {
+		var result []Term
+		for _, row := range t.Rows {
+			result = append(result, row.Term)
+		}
+		return result
+	}
+	return search.terms(search.best)
+}
+
+// Функция возвращает стоимость импликант в модели cost
+func Cost(cost logic.CostModel, terms []Term) int {
+	cubes := make([]logic.Cube, 0, len(terms))
+	for _, term := range terms {
+		cubes = append(cubes, term.Cube())
+	}
+	return cost.Cost(logic.DNF, cubes)
+}
+
+// Функция возвращает СДНФ от ФАЛ
+// Неопределенные наборы в СДНФ не входят; вектор проверяется logic.Arity
+func MakeSDNF(f []int) ([]Term, error) {
+	variableNumber, err := logic.Arity(f)
+	if err != nil {
+		return nil, err
+	}
+	sdnf := make([]Term, 0, len(f))
+	for i := range f {
+		if f[i] == 1 {
+			term := make(Term, 0, variableNumber)
+			for bit := variableNumber - 1; bit >= 0; bit-- {
+				if i&(1<<uint(bit)) != 0 {
+					term = append(term, True)
+				} else {
+					term = append(term, False)
+				}
+			}
+			sdnf = append(sdnf, term)
+		}
+	}
+	return sdnf, nil
+}
+
+// Функция форматирует импликанты в строку
+// К пр.: [10~1, 010~, ~1~~] -> "x3!x1x0 + !x2x1!x0 + x3!x1x0"
+func Format(impls []Term) string {
+	var result string
+	for i, impl := range impls {
+		if i != 0 {
+			result += " + "
+		}
+		result += impl.PrettyString()
+	}
+	return result
+}
+
+func PrettyString(terms []Term) string {
+	var formatted string
+	for i, impl := range terms {
+		if i != 0 {
+			formatted += " + "
+		}
+		formatted += impl.PrettyString()
+	}
+	return formatted
+}
+
+func String(terms []Term) string {
+	var formatted string
+	for i, impl := range terms {
+		if i != 0 {
+			formatted += ", "
+		}
+		formatted += impl.String()
+	}
+	return formatted
+}
+
@@ -0,0 +1,24 @@
+package qmc
+
+import "testing"
+
+func TestTermPrettyString(t *testing.T) {
+	tests := []struct {
+		term Term
+		want string
+	}{
+		{Term{Tilde, Tilde, True, False}, "!x3x2"},
+		{Term{True, False, Tilde}, "!x1x0"},
+		{Term{False}, "!x0"},
+		{Term{Tilde, Tilde, Tilde}, "1"},
+		{Term{}, "1"},
+	}
+	for _, test := range tests {
+		if got := test.term.PrettyString(); got != test.want {
+			t.Errorf("%s: %q, want %q", test.term, got, test.want)
+		}
+	}
+	if got := Format([]Term{{True, Tilde}, {Tilde, Tilde}}); got != "x0 + 1" {
+		t.Errorf("Format: %q", got)
+	}
+}
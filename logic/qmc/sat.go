@@ -0,0 +1,110 @@
+package qmc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/sat"
+)
+
+// Ошибка: SAT-кодировка стоимости требует аддитивной модели
+var ErrNotAdditive = errors.New("sat cover search needs an additive cost model")
+
+// Функция кодирует задачу покрытия таблицы t в КНФ
+// Переменная i+1 означает, что строка i входит в покрытие; каждому
+// столбцу соответствует дизъюнкт из строк, которые его покрывают, а
+// существенные строки входят в покрытие единичными дизъюнктами
+// Минимизируемая сумма вкладов строк записывается в комментарии
+// Возвращает формулу, литералы строк и их вклады в стоимость cost
+func (t Table) CoverCNF(essential map[int]struct{}, cost logic.CostModel) (sat.CNF, []sat.Lit, []int) {
+	var f sat.CNF
+	rows := f.Inputs(len(t.Rows))
+	weights := make([]int, len(t.Rows))
+	objective := "objective: minimize"
+	for i := range t.Rows {
+		weights[i] = cost.CubeCost(logic.DNF, t.Rows[i].Term.Cube())
+		objective += fmt.Sprintf(" +%d x%d", weights[i], rows[i])
+		if _, found := essential[i]; found {
+			f.Add(rows[i])
+		}
+	}
+	f.Comments = append(f.Comments,
+		fmt.Sprintf("unate covering problem: %d rows (prime implicants), %d columns (points)", len(t.Rows), len(t.Columns)),
+		fmt.Sprintf("cost model: %s", cost.Name()),
+		objective)
+	if !cost.Additive() {
+		f.Comments = append(f.Comments, "the cost model is not additive, the objective is a lower bound of the cost")
+	}
+	for i := range t.Rows {
+		f.Comments = append(f.Comments, fmt.Sprintf("x%d = %s (cost %d)", rows[i], t.Rows[i].Term.Cube().PrettyString(), weights[i]))
+	}
+	for j := range t.Columns {
+		var clause []sat.Lit
+		for i := range t.Rows {
+			if t.Marks[i][j] {
+				clause = append(clause, rows[i])
+			}
+		}
+		f.Add(clause...)
+	}
+	return f, rows, weights
+}
+
+// Функция кодирует задачу покрытия таблицы t как взвешенный MaxSAT:
+// дизъюнкты CoverCNF жесткие, а мягкий дизъюнкт !x с весом вклада строки
+// нарушается, когда строка входит в покрытие
+func (t Table) CoverWCNF(essential map[int]struct{}, cost logic.CostModel) sat.WCNF {
+	hard, rows, weights := t.CoverCNF(essential, cost)
+	f := sat.WCNF{Hard: hard}
+	for i, row := range rows {
+		if weights[i] != 0 {
+			f.AddSoft(weights[i], row.Not())
+		}
+	}
+	return f
+}
+
+// Функция ищет покрытие таблицы t минимальной стоимости SAT-решателем:
+// после каждого найденного покрытия стоимости c ищется покрытие стоимости
+// не больше c-1, пока формула не станет невыполнимой
+// Годится для таблиц, на которых перебор Step5 не успевает закончиться
+// Модель cost должна быть аддитивной, иначе возвращается ErrNotAdditive
+// Возвращает покрытие и признак того, что его минимальность доказана;
+// если tracker останавливает поиск, возвращается лучшее найденное
+// покрытие, а если его нет - покрытие из всех строк таблицы
+func MinimizeSAT(t Table, essential map[int]struct{}, cost logic.CostModel, tracker *logic.Tracker) ([]Term, bool, error) {
+	if !cost.Additive() {
+		return nil, false, ErrNotAdditive
+	}
+	base, rows, weights := t.CoverCNF(essential, cost)
+	var best []Term
+	bound := -1
+	for {
+		f := base
+		f.Clauses = append([]sat.Clause(nil), base.Clauses...)
+		if bound != -1 {
+			f.AtMost(rows, weights, bound-1)
+		}
+		status, model := sat.Solve(f, tracker)
+		if status == sat.Unknown {
+			break
+		}
+		if status == sat.Unsatisfiable {
+			return best, true, nil
+		}
+		best = nil
+		for i, row := range rows {
+			if model.Value(row) {
+				best = append(best, t.Rows[i].Term)
+			}
+		}
+		bound = Cost(cost, best)
+		tracker.Found(bound)
+	}
+	if best == nil {
+		for _, row := range t.Rows {
+			best = append(best, row.Term)
+		}
+	}
+	return best, false, nil
+}
@@ -0,0 +1,93 @@
+package qmc_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	"github.com/AndreevSemen/asvt/logic/qmc"
+	"github.com/AndreevSemen/asvt/logic/sat"
+	"testing"
+)
+
+// Модель кодировки таблицы покрытия выбирает строки, покрывающие все
+// столбцы и все существенные строки, а сумма весов равна стоимости строк
+func TestCoverCNF(t *testing.T) {
+	logictest.ForEachFunction(3, true, func(f []int) {
+		steps, err := qmc.Prepare(logic.NewSpec(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		table := steps.Table
+		formula, rows, weights := table.CoverCNF(steps.Essential, logic.LiteralCost{})
+		if len(formula.Clauses) != len(steps.Essential)+len(table.Columns) || len(rows) != len(table.Rows) {
+			t.Fatalf("%v: %d clauses for %d essential rows and %d columns", f, len(formula.Clauses), len(steps.Essential), len(table.Columns))
+		}
+		status, model := sat.Solve(formula, nil)
+		if status != sat.Satisfiable {
+			t.Fatalf("%v: covering problem is %s", f, status)
+		}
+		for j := range table.Columns {
+			var covered = false
+			for i := range table.Rows {
+				covered = covered || table.Marks[i][j] && model.Value(rows[i])
+			}
+			if !covered {
+				t.Errorf("%v: column %d is not covered", f, j)
+			}
+		}
+		for i := range table.Rows {
+			if _, found := steps.Essential[i]; found && !model.Value(rows[i]) {
+				t.Errorf("%v: essential row %d is not selected", f, i)
+			}
+			if weights[i] != table.Rows[i].Term.Cube().Literals() {
+				t.Errorf("%v: row %d weighs %d", f, i, weights[i])
+			}
+		}
+	})
+}
+
+func TestMinimizeSATNotAdditive(t *testing.T) {
+	spec, err := logic.ParseSpec("01111110")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = logic.Minimize(context.Background(), "qmc-sat", spec, logic.Options{Cost: logic.TransistorCost{}})
+	if err != qmc.ErrNotAdditive {
+		t.Errorf("qmc-sat with transistor cost: %v", err)
+	}
+}
+
+func TestCoverWCNF(t *testing.T) {
+	spec, err := logic.ParseSpec("0111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, err := qmc.Prepare(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	if err := steps.Table.CoverWCNF(steps.Essential, logic.GateInputCost{}).WriteWCNF(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	// Существенные строки и столбцы жесткие, а мягкие дизъюнкты запрещают
+	// строки с весом их вклада
+	want := `c unate covering problem: 2 rows (prime implicants), 3 columns (points)
+c cost model: gates
+c objective: minimize +2 x1 +2 x2
+c x1 = x1 (cost 2)
+c x2 = x0 (cost 2)
+p wcnf 2 7 5
+5 1 0
+5 2 0
+5 1 0
+5 2 0
+5 1 2 0
+2 -1 0
+2 -2 0
+`
+	if buffer.String() != want {
+		t.Errorf("WCNF\n%s\nwant\n%s", buffer.String(), want)
+	}
+}
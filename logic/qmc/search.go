@@ -0,0 +1,192 @@
+package qmc
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"sort"
+)
+
+// Состояние потокового перебора покрытий шага 5
+// Комбинации несущественных строк не накапливаются, а перебираются
+// по одной в глубину, покрытие столбцов проверяется по битовым множествам
+// Общие поля после создания только читаются, поэтому ветви перебора
+// можно выполнять параллельно
+type coverSearch struct {
+	t       Table
+	cost    logic.CostModel
+	tracker *logic.Tracker
+	// Все столбцы таблицы
+	all bitset
+	// Столбцы, которые покрывает каждая строка
+	rows []bitset
+	// Существенные строки, их столбцы и сумма вкладов в стоимость
+	essential     []int
+	essentialSet  bitset
+	essentialCost int
+	// Несущественные строки в порядке возрастания вклада в стоимость
+	candidates []int
+	// prefix[k] - сумма вкладов k самых дешевых кандидатов,
+	// prefix[p+k]-prefix[p] - k кандидатов начиная с позиции p
+	prefix []int
+	// suffix[p] - столбцы, которые покрывают кандидаты начиная с позиции p
+	suffix []bitset
+
+	// Общий рекорд параллельных ветвей
+	bound    *logic.SharedBound
+	best     []int
+	bestCost int
+	found    bool
+}
+
+func newCoverSearch(t Table, essential map[int]struct{}, cost logic.CostModel, tracker *logic.Tracker) *coverSearch {
+	s := &coverSearch{
+		t:            t,
+		cost:         cost,
+		tracker:      tracker,
+		all:          newBitset(len(t.Columns)),
+		rows:         t.rowSets(),
+		essentialSet: newBitset(len(t.Columns)),
+		bound:        logic.NewSharedBound(),
+	}
+	for j := range t.Columns {
+		s.all.set(j)
+	}
+	cubeCosts := make([]int, len(t.Rows))
+	for i := range t.Rows {
+		cubeCosts[i] = cost.CubeCost(logic.DNF, t.Rows[i].Term.Cube())
+		if _, found := essential[i]; found {
+			s.essential = append(s.essential, i)
+			s.essentialSet = s.essentialSet.or(s.rows[i])
+			s.essentialCost += cubeCosts[i]
+		} else {
+			s.candidates = append(s.candidates, i)
+		}
+	}
+	sort.SliceStable(s.candidates, func(a, b int) bool {
+		return cubeCosts[s.candidates[a]] < cubeCosts[s.candidates[b]]
+	})
+	s.prefix = make([]int, len(s.candidates)+1)
+	for p, i := range s.candidates {
+		s.prefix[p+1] = s.prefix[p] + cubeCosts[i]
+	}
+	s.suffix = make([]bitset, len(s.candidates)+1)
+	s.suffix[len(s.candidates)] = newBitset(len(t.Columns))
+	for p := len(s.candidates) - 1; p >= 0; p-- {
+		s.suffix[p] = s.suffix[p+1].or(s.rows[s.candidates[p]])
+	}
+	return s
+}
+
+// Лучшее покрытие, найденное в одной ветви перебора
+type coverBranch struct {
+	best  []int
+	cost  int
+	found bool
+}
+
+// Функция сообщает, может ли продолжение комбинации кандидатом с позиции p
+// и еще remaining-1 следующими дать покрытие лучше рекорда для ветви branch
+// Если не может, то не могут и продолжения с последующих позиций
+func (s *coverSearch) promising(branch, p, remaining int, covered bitset, cubeCost int) bool {
+	// Оставшиеся кандидаты должны покрыть таблицу
+	if !covered.or(s.suffix[p]).containsAll(s.all) {
+		return false
+	}
+	// Кандидаты упорядочены по вкладу, поэтому самое дешевое продолжение -
+	// следующие remaining кандидатов
+	return !s.bound.Prunes(cubeCost+s.prefix[p+remaining]-s.prefix[p], branch)
+}
+
+// Функция перебирает комбинации из k кандидатов, продолжающие chosen
+// начиная с позиции start; covered - покрытые столбцы, cubeCost - сумма
+// вкладов выбранных строк вместе с существенными
+// Возвращает false, если перебор остановлен
+func (s *coverSearch) combinations(b *coverBranch, branch, k, start int, chosen []int, covered bitset, cubeCost int) bool {
+	if !s.tracker.Step() {
+		return false
+	}
+	if len(chosen) == k {
+		if covered.containsAll(s.all) {
+			s.evaluate(b, branch, chosen)
+		}
+		return true
+	}
+	// Комбинация, которая уже покрывает таблицу, содержит покрытие меньшего
+	// размера, найденное раньше, поэтому ее надмножества пропускаются
+	if covered.containsAll(s.all) {
+		return true
+	}
+	remaining := k - len(chosen)
+	for p := start; p <= len(s.candidates)-remaining; p++ {
+		if !s.promising(branch, p, remaining, covered, cubeCost) {
+			break
+		}
+		i := s.candidates[p]
+		next := append(chosen[:len(chosen):len(chosen)], i)
+		cube := s.prefix[p+1] - s.prefix[p]
+		if !s.combinations(b, branch, k, p+1, next, covered.or(s.rows[i]), cubeCost+cube) {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция оценивает покрывающую комбинацию и запоминает ее, если она
+// лучше рекорда; при равной стоимости остается найденная раньше
+func (s *coverSearch) evaluate(b *coverBranch, branch int, chosen []int) {
+	rows := append(append([]int(nil), s.essential...), chosen...)
+	sort.Ints(rows)
+	cost := Cost(s.cost, s.terms(rows))
+	if s.bound.Improve(cost, branch) {
+		b.best = rows
+		b.cost = cost
+		b.found = true
+		s.tracker.Found(cost)
+	}
+}
+
+func (s *coverSearch) terms(rows []int) []Term {
+	terms := make([]Term, 0, len(rows))
+	for _, i := range rows {
+		terms = append(terms, s.t.Rows[i].Term)
+	}
+	return terms
+}
+
+// Функция перебирает комбинации из k = 1, 2, ... несущественных строк
+// и останавливается на первом k, при котором ни одна комбинация не может
+// оказаться дешевле найденного покрытия: стоимость не меньше суммы вкладов
+// кубов, поэтому для модели terms перебор заканчивается на первом k,
+// при котором покрытие существует
+// Ветви перебора - комбинации с разными первыми кандидатами; они
+// нумеруются в порядке последовательного перебора и раздаются потокам
+// tracker.Workers(), а общий рекорд делает результат независимым
+// от числа потоков
+func (s *coverSearch) run() {
+	first := 0
+	for k := 1; k <= len(s.candidates); k++ {
+		if s.bound.Prunes(s.essentialCost+s.prefix[k], first) {
+			break
+		}
+		s.tracker.SetStage(fmt.Sprintf("step 5: combinations of %d rows", k))
+		branches := make([]coverBranch, len(s.candidates)-k+1)
+		logic.RunBranches(len(branches), s.tracker.Workers(), func(p int) {
+			if !s.promising(first+p, p, k, s.essentialSet, s.essentialCost) {
+				return
+			}
+			i := s.candidates[p]
+			cube := s.prefix[p+1] - s.prefix[p]
+			s.combinations(&branches[p], first+p, k, p+1, []int{i}, s.essentialSet.or(s.rows[i]), s.essentialCost+cube)
+		})
+		// Рекорд хранит ветвь, в которой найдено лучшее покрытие
+		if cost, branch := s.bound.Best(); branch >= first && branch < first+len(branches) {
+			s.best = branches[branch-first].best
+			s.bestCost = cost
+			s.found = true
+		}
+		if s.tracker.Err() != nil {
+			break
+		}
+		first += len(branches)
+	}
+}
@@ -0,0 +1,119 @@
+package qmc
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	a, b := newBitset(130), newBitset(130)
+	if len(a) != 3 {
+		t.Fatalf("%d words for 130 elements", len(a))
+	}
+	for _, j := range []int{0, 63, 64, 129} {
+		a.set(j)
+	}
+	b.set(64)
+	if !a.has(63) || !a.has(64) || a.has(65) || a.count() != 4 {
+		t.Errorf("bitset %v", a)
+	}
+	if !a.containsAll(b) || b.containsAll(a) {
+		t.Error("containsAll is wrong across words")
+	}
+	b.set(100)
+	union := a.or(b)
+	if union.count() != 5 || a.count() != 4 {
+		t.Errorf("union of %d elements, a of %d", union.count(), a.count())
+	}
+}
+
+// Функция возвращает наименьшую стоимость покрытия таблицы перебором
+// всех наборов ее строк
+func bruteForceStep5(t Table, cost logic.CostModel) int {
+	best := -1
+	sets := t.rowSets()
+	all := newBitset(len(t.Columns))
+	for j := range t.Columns {
+		all.set(j)
+	}
+	for subset := 0; subset < 1<<uint(len(t.Rows)); subset++ {
+		covered := newBitset(len(t.Columns))
+		var terms []Term
+		for i := range t.Rows {
+			if subset&(1<<uint(i)) != 0 {
+				covered = covered.or(sets[i])
+				terms = append(terms, t.Rows[i].Term)
+			}
+		}
+		if !covered.containsAll(all) {
+			continue
+		}
+		if c := Cost(cost, terms); best == -1 || c < best {
+			best = c
+		}
+	}
+	return best
+}
+
+// Step5 находит самое дешевое покрытие таблицы на всех ФАЛ от 3 переменных
+// во всех моделях стоимости, включая неаддитивную
+func TestStep5(t *testing.T) {
+	models := []logic.CostModel{
+		logic.LiteralCost{},
+		logic.TermCost{},
+		logic.GateInputCost{},
+		logic.TransistorCost{},
+		logic.WeightedCost{Weights: []int{1, 4, 2}},
+		logic.ComplementPenalty{Base: logic.LiteralCost{}, Penalty: 2},
+	}
+	f := make([]int, 8)
+	for i := 0; i < 256; i++ {
+		for point := range f {
+			f[point] = i >> uint(point) & 1
+		}
+		steps, err := Prepare(logic.NewSpec(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps.Source) == 0 {
+			continue
+		}
+		for _, model := range models {
+			tracker := logic.NewTracker(context.Background(), logic.Options{})
+			result := Step5(steps.Table, steps.Essential, model, tracker)
+			if got, want := Cost(model, result), bruteForceStep5(steps.Table, model); got != want {
+				t.Errorf("%v (%s): %s costs %d, want %d", f, model.Name(), Format(result), got, want)
+			}
+			if mismatches, _ := MakeCover(3, result).Verify(f); len(mismatches) != 0 {
+				t.Errorf("%v (%s): %v", f, model.Name(), mismatches)
+			}
+		}
+	}
+}
+
+// Таблица из 96 столбцов занимает два слова множеств строк
+func TestStep5WideTable(t *testing.T) {
+	// Циклическая ФАЛ от x0, x1, x2, не зависящая от x3..x6:
+	// существенных строк нет, и покрытие ищется перебором
+	cyclic := []int{0, 1, 1, 1, 1, 1, 1, 0}
+	f := make([]int, 128)
+	for point := range f {
+		f[point] = cyclic[point>>4]
+	}
+	steps, err := Prepare(logic.NewSpec(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps.Table.Columns) != 96 || len(steps.Essential) != 0 {
+		t.Fatalf("%d columns, %d essential rows", len(steps.Table.Columns), len(steps.Essential))
+	}
+	tracker := logic.NewTracker(context.Background(), logic.Options{})
+	result := Step5(steps.Table, steps.Essential, logic.LiteralCost{}, tracker)
+	if got := Cost(logic.LiteralCost{}, result); got != 6 || len(result) != 3 {
+		t.Errorf("result %s costs %d, want 3 products of 6 literals", Format(result), got)
+	}
+	if mismatches, _ := MakeCover(7, result).Verify(f); len(mismatches) != 0 {
+		t.Errorf("%s: %v", Format(result), mismatches)
+	}
+}
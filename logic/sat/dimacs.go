@@ -0,0 +1,123 @@
+package sat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Ошибка в файле DIMACS
+type DIMACSError struct {
+	Line   int
+	Reason string
+}
+
+func (e *DIMACSError) Error() string {
+	return fmt.Sprintf("dimacs line %d: %s", e.Line, e.Reason)
+}
+
+// Функция читает формулу в формате DIMACS CNF
+// Комментарии (строки "c ...") сохраняются в Comments, дизъюнкт может
+// занимать несколько строк и заканчивается нулем
+func ParseDIMACS(r io.Reader) (CNF, error) {
+	var f CNF
+	var clause Clause
+	header := false
+	declared := 0
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || text == "%":
+			continue
+		case strings.HasPrefix(text, "c"):
+			f.Comments = append(f.Comments, strings.TrimSpace(strings.TrimPrefix(text, "c")))
+			continue
+		case strings.HasPrefix(text, "p"):
+			fields := strings.Fields(text)
+			if header || len(fields) != 4 || fields[1] != "cnf" {
+				return CNF{}, &DIMACSError{Line: line, Reason: "bad problem line " + strconv.Quote(text)}
+			}
+			variables, errV := strconv.Atoi(fields[2])
+			clauses, errC := strconv.Atoi(fields[3])
+			if errV != nil || errC != nil || variables < 0 || clauses < 0 {
+				return CNF{}, &DIMACSError{Line: line, Reason: "bad problem line " + strconv.Quote(text)}
+			}
+			f.Variables = variables
+			declared = clauses
+			header = true
+			continue
+		}
+		if !header {
+			return CNF{}, &DIMACSError{Line: line, Reason: "clause before problem line"}
+		}
+		for _, field := range strings.Fields(text) {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return CNF{}, &DIMACSError{Line: line, Reason: "bad literal " + strconv.Quote(field)}
+			}
+			if value == 0 {
+				f.Clauses = append(f.Clauses, clause)
+				clause = nil
+				continue
+			}
+			if Lit(value).Var() > f.Variables {
+				return CNF{}, &DIMACSError{Line: line, Reason: fmt.Sprintf("literal %d exceeds %d variables", value, f.Variables)}
+			}
+			clause = append(clause, Lit(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return CNF{}, err
+	}
+	if !header {
+		return CNF{}, &DIMACSError{Line: line, Reason: "missing problem line"}
+	}
+	if len(clause) != 0 {
+		f.Clauses = append(f.Clauses, clause)
+	}
+	if len(f.Clauses) != declared {
+		return CNF{}, &DIMACSError{Line: line, Reason: fmt.Sprintf("%d clauses declared, %d found", declared, len(f.Clauses))}
+	}
+	return f, nil
+}
+
+// Функция записывает формулу в формате DIMACS CNF
+func (f CNF) WriteDIMACS(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	for _, comment := range f.Comments {
+		fmt.Fprintf(out, "c %s\n", comment)
+	}
+	fmt.Fprintf(out, "p cnf %d %d\n", f.Variables, len(f.Clauses))
+	for _, clause := range f.Clauses {
+		for _, l := range clause {
+			fmt.Fprintf(out, "%d ", l)
+		}
+		fmt.Fprintln(out, 0)
+	}
+	return out.Flush()
+}
+
+// Функция записывает результат решения в формате соревнований:
+// строка "s СТАТУС" и для выполнимой формулы строки "v" со значениями
+func WriteSolution(w io.Writer, status Status, model Model) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "s %s\n", status)
+	if status == Satisfiable {
+		fmt.Fprint(out, "v")
+		for v := 1; v < len(model); v++ {
+			if model[v] {
+				fmt.Fprintf(out, " %d", v)
+			} else {
+				fmt.Fprintf(out, " %d", -v)
+			}
+		}
+		fmt.Fprintln(out, " 0")
+	}
+	return out.Flush()
+}
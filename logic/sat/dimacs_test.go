@@ -0,0 +1,111 @@
+package sat
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func clausesString(f CNF) string {
+	var buffer bytes.Buffer
+	f.Comments = nil
+	if err := f.WriteDIMACS(&buffer); err != nil {
+		panic(err)
+	}
+	return buffer.String()
+}
+
+// Записанная формула читается обратно без изменений
+func TestDIMACSRoundTrip(t *testing.T) {
+	formulas := []CNF{
+		{Variables: 0},
+		{Variables: 3, Clauses: []Clause{{1, -2}, {3}, {-1, 2, -3}}, Comments: []string{"example", "objective: minimize +1 x1"}},
+		{Variables: 2, Clauses: []Clause{{}, {2}}},
+		pigeonhole(3, 2),
+	}
+	for _, f := range formulas {
+		var buffer bytes.Buffer
+		if err := f.WriteDIMACS(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		written := buffer.String()
+		parsed, err := ParseDIMACS(&buffer)
+		if err != nil {
+			t.Fatalf("%q: %v", written, err)
+		}
+		if parsed.Variables != f.Variables || strings.Join(parsed.Comments, "\n") != strings.Join(f.Comments, "\n") {
+			t.Errorf("%q: parsed %d variables, comments %q", written, parsed.Variables, parsed.Comments)
+		}
+		if clausesString(parsed) != clausesString(f) {
+			t.Errorf("%q: parsed\n%s", written, clausesString(parsed))
+		}
+	}
+}
+
+func TestParseDIMACS(t *testing.T) {
+	input := `c comment
+p cnf 3 2
+1 -2
+  3 0
+%
+-1 0
+`
+	f, err := ParseDIMACS(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "p cnf 3 2\n1 -2 3 0\n-1 0\n"; clausesString(f) != want || len(f.Comments) != 1 || f.Comments[0] != "comment" {
+		t.Errorf("parsed %q, comments %q", clausesString(f), f.Comments)
+	}
+	// Последний дизъюнкт может не заканчиваться нулем
+	f, err = ParseDIMACS(strings.NewReader("p cnf 2 1\n1 2"))
+	if err != nil || len(f.Clauses) != 1 || len(f.Clauses[0]) != 2 {
+		t.Errorf("unterminated clause: %v, %v", f.Clauses, err)
+	}
+}
+
+func TestParseDIMACSErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "dimacs line 0: missing problem line"},
+		{"1 2 0\n", "dimacs line 1: clause before problem line"},
+		{"p cnf 2\n", `dimacs line 1: bad problem line "p cnf 2"`},
+		{"p dnf 2 1\n", `dimacs line 1: bad problem line "p dnf 2 1"`},
+		{"p cnf 2 1\np cnf 2 1\n", `dimacs line 2: bad problem line "p cnf 2 1"`},
+		{"p cnf -1 0\n", `dimacs line 1: bad problem line "p cnf -1 0"`},
+		{"p cnf 2 1\n1 x 0\n", `dimacs line 2: bad literal "x"`},
+		{"p cnf 2 1\n1 -3 0\n", "dimacs line 2: literal -3 exceeds 2 variables"},
+		{"p cnf 2 2\n1 0\n", "dimacs line 2: 2 clauses declared, 1 found"},
+	}
+	for _, test := range tests {
+		_, err := ParseDIMACS(strings.NewReader(test.input))
+		var dimacsErr *DIMACSError
+		if !errors.As(err, &dimacsErr) || err.Error() != test.err {
+			t.Errorf("%q: error %v, want %s", test.input, err, test.err)
+		}
+	}
+}
+
+func TestWriteSolution(t *testing.T) {
+	tests := []struct {
+		status Status
+		model  Model
+		want   string
+	}{
+		{Satisfiable, Model{false, true, false, true}, "s SATISFIABLE\nv 1 -2 3 0\n"},
+		{Unsatisfiable, nil, "s UNSATISFIABLE\n"},
+		{Unknown, nil, "s UNKNOWN\n"},
+	}
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		if err := WriteSolution(&buffer, test.status, test.model); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != test.want {
+			t.Errorf("%s: %q, want %q", test.status, buffer.String(), test.want)
+		}
+	}
+}
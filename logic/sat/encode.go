@@ -0,0 +1,168 @@
+package sat
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+)
+
+// Функция добавляет ограничение: сумма весов истинных литералов lits
+// не больше bound
+// Кодировка - взвешенный последовательный счетчик: переменная s[i][j]
+// означает, что сумма весов истинных литералов среди первых i+1 не меньше j,
+// поэтому вспомогательных переменных не больше len(lits)*bound
+func (f *CNF) AtMost(lits []Lit, weights []int, bound int) {
+	if bound < 0 {
+		f.Add()
+		return
+	}
+	var previous []Lit
+	for i, x := range lits {
+		w := weights[i]
+		if w == 0 {
+			continue
+		}
+		if w > bound {
+			f.Add(x.Not())
+			continue
+		}
+		// counter[j-1] - сумма с учетом x не меньше j
+		counter := make([]Lit, bound)
+		for j := range counter {
+			counter[j] = f.NewVariable()
+		}
+		for j := 1; j <= w; j++ {
+			f.Add(x.Not(), counter[j-1])
+		}
+		for j := 1; j <= len(previous); j++ {
+			f.Add(previous[j-1].Not(), counter[j-1])
+			if j+w <= bound {
+				f.Add(x.Not(), previous[j-1].Not(), counter[j+w-1])
+			} else {
+				f.Add(x.Not(), previous[j-1].Not())
+			}
+		}
+		previous = counter
+	}
+}
+
+// Функция возвращает литерал, равный конъюнкции lits (преобразование Цейтина)
+// Конъюнкция пустого набора равна 1
+func (f *CNF) And(lits ...Lit) Lit {
+	g := f.NewVariable()
+	long := Clause{g}
+	for _, l := range lits {
+		f.Add(g.Not(), l)
+		long = append(long, l.Not())
+	}
+	f.Add(long...)
+	return g
+}
+
+// Функция возвращает литерал, равный дизъюнкции lits
+// Дизъюнкция пустого набора равна 0
+func (f *CNF) Or(lits ...Lit) Lit {
+	negated := make([]Lit, len(lits))
+	for i, l := range lits {
+		negated[i] = l.Not()
+	}
+	return f.And(negated...).Not()
+}
+
+// Функция возвращает литерал, равный сумме a и b по модулю 2
+func (f *CNF) Xor(a, b Lit) Lit {
+	g := f.NewVariable()
+	f.Add(g.Not(), a, b)
+	f.Add(g.Not(), a.Not(), b.Not())
+	f.Add(g, a.Not(), b)
+	f.Add(g, a, b.Not())
+	return g
+}
+
+// Функция возвращает литералы переменных x0..x(n-1) - первые n переменных
+// формулы, которые заводятся, если их еще нет
+func (f *CNF) Inputs(n int) []Lit {
+	if f.Variables < n {
+		f.Variables = n
+	}
+	inputs := make([]Lit, n)
+	for i := range inputs {
+		inputs[i] = Lit(i + 1)
+	}
+	return inputs
+}
+
+// Функция кодирует покрытие схемой из вентилей И/ИЛИ/XOR и возвращает
+// литерал ее выхода; inputs[i] - литерал переменной x_i
+func (f *CNF) Cover(inputs []Lit, cover logic.Cover) Lit {
+	cubes := make([]Lit, 0, len(cover.Cubes))
+	for _, cube := range cover.Cubes {
+		var lits []Lit
+		for i, input := range inputs {
+			bit := uint32(1) << uint(i)
+			if cube.Mask&bit == 0 {
+				continue
+			}
+			// Литерал конъюнкции ДНФ совпадает со значением куба,
+			// а литерал дизъюнкта КНФ, нулевого на кубе, - противоположен
+			positive := cube.Values&bit != 0
+			if cover.Form == logic.CNF {
+				positive = !positive
+			}
+			if positive {
+				lits = append(lits, input)
+			} else {
+				lits = append(lits, input.Not())
+			}
+		}
+		if cover.Form == logic.CNF {
+			cubes = append(cubes, f.Or(lits...))
+		} else {
+			cubes = append(cubes, f.And(lits...))
+		}
+	}
+	switch cover.Form {
+	case logic.CNF:
+		return f.And(cubes...)
+	case logic.ESOP:
+		// Сумма по модулю 2 пустого набора равна 0
+		sum := f.Or()
+		for _, cube := range cubes {
+			sum = f.Xor(sum, cube)
+		}
+		return sum
+	}
+	return f.Or(cubes...)
+}
+
+// Функция проверяет эквивалентность покрытий a и b без перебора наборов:
+// формула "выходы схем различаются" невыполнима, только если покрытия
+// эквивалентны
+// Если покрытия различаются, возвращается номер набора, на котором
+// различаются их значения
+// Если tracker останавливает перебор, возвращается ошибка остановки
+func Equivalent(a, b logic.Cover, tracker *logic.Tracker) (bool, int, error) {
+	if a.Variables != b.Variables {
+		return false, 0, &logic.ArityError{Expected: a.Variables, Actual: b.Variables}
+	}
+	var f CNF
+	inputs := f.Inputs(a.Variables)
+	outA, outB := f.Cover(inputs, a), f.Cover(inputs, b)
+	f.Add(outA, outB)
+	f.Add(outA.Not(), outB.Not())
+
+	status, model := Solve(f, tracker)
+	switch status {
+	case Unsatisfiable:
+		return true, 0, nil
+	case Satisfiable:
+		point := 0
+		for _, input := range inputs {
+			point <<= 1
+			if model.Value(input) {
+				point |= 1
+			}
+		}
+		return false, point, nil
+	default:
+		return false, 0, tracker.Err()
+	}
+}
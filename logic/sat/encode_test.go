@@ -0,0 +1,127 @@
+package sat
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+	"testing"
+)
+
+// Ограничение AtMost выполнимо ровно на тех наборах литералов, где сумма
+// весов истинных не больше границы
+func TestAtMost(t *testing.T) {
+	tests := []struct {
+		weights []int
+		bound   int
+	}{
+		{[]int{1, 1, 1, 1}, 0},
+		{[]int{1, 1, 1, 1}, 2},
+		{[]int{1, 1, 1, 1}, 4},
+		{[]int{3, 1, 2, 0}, 3},
+		{[]int{2, 5, 1}, 4},
+		{[]int{1, 2}, -1},
+	}
+	for _, test := range tests {
+		for assignment := 0; assignment < 1<<uint(len(test.weights)); assignment++ {
+			var f CNF
+			lits := f.Inputs(len(test.weights))
+			f.AtMost(lits, test.weights, test.bound)
+			sum := 0
+			for i, l := range lits {
+				if assignment&(1<<uint(i)) != 0 {
+					f.Add(l)
+					sum += test.weights[i]
+				} else {
+					f.Add(l.Not())
+				}
+			}
+			status, _ := Solve(f, nil)
+			if (status == Satisfiable) != (sum <= test.bound) {
+				t.Errorf("weights %v, bound %d, sum %d: %s", test.weights, test.bound, sum, status)
+			}
+		}
+	}
+}
+
+// Вентили Цейтина равны своим функциям на всех наборах входов
+func TestGates(t *testing.T) {
+	gates := []struct {
+		name string
+		make func(f *CNF, a, b Lit) Lit
+		eval func(a, b bool) bool
+	}{
+		{"and", func(f *CNF, a, b Lit) Lit { return f.And(a, b) }, func(a, b bool) bool { return a && b }},
+		{"or", func(f *CNF, a, b Lit) Lit { return f.Or(a, b) }, func(a, b bool) bool { return a || b }},
+		{"xor", func(f *CNF, a, b Lit) Lit { return f.Xor(a, b) }, func(a, b bool) bool { return a != b }},
+		{"empty and", func(f *CNF, a, b Lit) Lit { return f.And() }, func(a, b bool) bool { return true }},
+		{"empty or", func(f *CNF, a, b Lit) Lit { return f.Or() }, func(a, b bool) bool { return false }},
+	}
+	for _, gate := range gates {
+		for assignment := 0; assignment < 4; assignment++ {
+			a, b := assignment&1 != 0, assignment&2 != 0
+			var f CNF
+			inputs := f.Inputs(2)
+			out := gate.make(&f, inputs[0], inputs[1])
+			for i, value := range []bool{a, b} {
+				if value {
+					f.Add(inputs[i])
+				} else {
+					f.Add(inputs[i].Not())
+				}
+			}
+			status, model := Solve(f, nil)
+			if status != Satisfiable || model.Value(out) != gate.eval(a, b) {
+				t.Errorf("%s(%t, %t): %s, output %v", gate.name, a, b, status, model)
+			}
+		}
+	}
+}
+
+// Функция вычисляет значение покрытия на наборе point
+func value(c logic.Cover, point int) int {
+	zeros := make([]int, 1<<uint(c.Variables))
+	mismatches, err := c.Verify(zeros)
+	if err != nil {
+		panic(err)
+	}
+	for _, mismatch := range mismatches {
+		if mismatch.Point == point {
+			return 1
+		}
+	}
+	return 0
+}
+
+func TestEquivalent(t *testing.T) {
+	cover := func(form logic.Form, cubes ...logic.Cube) logic.Cover {
+		return logic.Cover{Variables: 2, Form: form, Cubes: cubes}
+	}
+	x0 := logic.Cube{Mask: 1, Values: 1}
+	x1 := logic.Cube{Mask: 2, Values: 2}
+	tests := []struct {
+		name       string
+		a, b       logic.Cover
+		equivalent bool
+		point      int
+	}{
+		{"x0 + x1 in dnf and cnf", cover(logic.DNF, x0, x1), cover(logic.CNF, logic.Cube{Mask: 3}), true, 0},
+		{"xor in esop and dnf", cover(logic.ESOP, x0, x1), cover(logic.DNF, logic.Cube{Mask: 3, Values: 1}, logic.Cube{Mask: 3, Values: 2}), true, 0},
+		{"constants", cover(logic.DNF, logic.Cube{}), cover(logic.CNF), true, 0},
+		{"or and xor differ at 11", cover(logic.DNF, x0, x1), cover(logic.ESOP, x0, x1), false, 3},
+		{"x0 and x1 differ", cover(logic.DNF, x0), cover(logic.DNF, x0, x1), false, 1},
+		{"0 and 1", cover(logic.DNF), cover(logic.ESOP, logic.Cube{}), false, -1},
+	}
+	for _, test := range tests {
+		equivalent, point, err := Equivalent(test.a, test.b, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if equivalent != test.equivalent || test.point >= 0 && point != test.point {
+			t.Errorf("%s: equivalent %t at %d, want %t at %d", test.name, equivalent, point, test.equivalent, test.point)
+		}
+		if !equivalent && value(test.a, point) == value(test.b, point) {
+			t.Errorf("%s: covers agree at %d", test.name, point)
+		}
+	}
+	if _, _, err := Equivalent(cover(logic.DNF), logic.Cover{Variables: 3}, nil); err == nil {
+		t.Error("covers of 2 and 3 variables compared")
+	}
+}
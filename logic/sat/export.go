@@ -0,0 +1,103 @@
+package sat
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"io"
+)
+
+// Функция возвращает СКНФ функции spec: по дизъюнкту на каждый нулевой
+// набор; переменная i+1 формулы - x_i
+// Неопределенные наборы не ограничиваются, то есть доопределяются единицей
+func SKNF(spec logic.Spec) CNF {
+	var f CNF
+	inputs := f.Inputs(spec.Variables)
+	f.Comments = append(f.Comments, fmt.Sprintf("SKNF of a function of %d variables, variable i+1 is x_i", spec.Variables))
+	for _, point := range spec.Points(0) {
+		clause := make(Clause, 0, len(inputs))
+		for i, input := range inputs {
+			// Дизъюнкт обращается в ноль только на наборе point
+			if point&(1<<uint(spec.Variables-1-i)) != 0 {
+				clause = append(clause, input.Not())
+			} else {
+				clause = append(clause, input)
+			}
+		}
+		f.Add(clause...)
+	}
+	return f
+}
+
+// Функция возвращает формулу, модели которой на переменных 1..n - наборы,
+// где покрытие cover равно 1; переменная i+1 формулы - x_i
+// Покрытие в КНФ записывается своими дизъюнктами, а покрытие в ДНФ
+// или ESOP - схемой Цейтина с дополнительными переменными после входов
+func FunctionCNF(cover logic.Cover) CNF {
+	var f CNF
+	inputs := f.Inputs(cover.Variables)
+	f.Comments = append(f.Comments, fmt.Sprintf("%s cover of a function of %d variables, variable i+1 is x_i", cover.Form, cover.Variables))
+	f.Comments = append(f.Comments, cover.PrettyString())
+	if cover.Form != logic.CNF {
+		f.Add(f.Cover(inputs, cover))
+		return f
+	}
+	for _, cube := range cover.Cubes {
+		var clause Clause
+		for i, input := range inputs {
+			bit := uint32(1) << uint(i)
+			if cube.Mask&bit == 0 {
+				continue
+			}
+			if cube.Values&bit != 0 {
+				clause = append(clause, input.Not())
+			} else {
+				clause = append(clause, input)
+			}
+		}
+		f.Add(clause...)
+	}
+	return f
+}
+
+// Формула для задачи взвешенного MaxSAT: жесткие дизъюнкты должны
+// выполняться, а сумма весов невыполненных мягких - быть минимальной
+type WCNF struct {
+	Hard    CNF
+	Soft    []Clause
+	Weights []int
+}
+
+// Функция добавляет мягкий дизъюнкт с весом weight
+func (f *WCNF) AddSoft(weight int, lits ...Lit) {
+	f.Soft = append(f.Soft, append(Clause(nil), lits...))
+	f.Weights = append(f.Weights, weight)
+}
+
+// Функция записывает формулу в формате WCNF: вес жестких дизъюнктов
+// (top) больше суммы весов всех мягких
+func (f WCNF) WriteWCNF(w io.Writer) error {
+	top := 1
+	for _, weight := range f.Weights {
+		top += weight
+	}
+	out := bufio.NewWriter(w)
+	for _, comment := range f.Hard.Comments {
+		fmt.Fprintf(out, "c %s\n", comment)
+	}
+	fmt.Fprintf(out, "p wcnf %d %d %d\n", f.Hard.Variables, len(f.Hard.Clauses)+len(f.Soft), top)
+	write := func(weight int, clause Clause) {
+		fmt.Fprintf(out, "%d", weight)
+		for _, l := range clause {
+			fmt.Fprintf(out, " %d", l)
+		}
+		fmt.Fprintln(out, " 0")
+	}
+	for _, clause := range f.Hard.Clauses {
+		write(top, clause)
+	}
+	for i, clause := range f.Soft {
+		write(f.Weights[i], clause)
+	}
+	return out.Flush()
+}
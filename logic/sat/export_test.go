@@ -0,0 +1,119 @@
+package sat
+
+import (
+	"bytes"
+	"github.com/AndreevSemen/asvt/logic"
+	"testing"
+)
+
+// Функция проверяет выполнимость формулы f, в которой переменные x_i
+// (1..n) фиксированы значениями набора point
+func satisfiableAt(f CNF, n, point int) bool {
+	f.Clauses = append([]Clause(nil), f.Clauses...)
+	for i := 0; i < n; i++ {
+		if point&(1<<uint(n-1-i)) != 0 {
+			f.Add(Lit(i + 1))
+		} else {
+			f.Add(Lit(i + 1).Not())
+		}
+	}
+	status, _ := Solve(f, nil)
+	return status == Satisfiable
+}
+
+// СКНФ выполнима ровно на единичных и неопределенных наборах
+func TestSKNF(t *testing.T) {
+	f := make([]int, 8)
+	var fill func(point int)
+	fill = func(point int) {
+		if point == len(f) {
+			formula := SKNF(logic.NewSpec(f))
+			if formula.Variables != 3 {
+				t.Fatalf("%v: %d variables", f, formula.Variables)
+			}
+			for point, value := range f {
+				if satisfiableAt(formula, 3, point) != (value != 0) {
+					t.Errorf("%v: SKNF at %d", f, point)
+				}
+			}
+			return
+		}
+		for _, value := range []int{0, 1, logic.DontCare} {
+			f[point] = value
+			fill(point + 1)
+		}
+	}
+	fill(0)
+}
+
+// Формула покрытия выполнима ровно на наборах, где покрытие равно 1, и
+// переживает запись в DIMACS
+func TestFunctionCNF(t *testing.T) {
+	var cubes []logic.Cube
+	for mask := uint32(0); mask < 4; mask++ {
+		for values := uint32(0); values < 4; values++ {
+			if values&^mask == 0 {
+				cubes = append(cubes, logic.Cube{Mask: mask, Values: values})
+			}
+		}
+	}
+	for _, form := range []logic.Form{logic.DNF, logic.CNF, logic.ESOP} {
+		for a := -1; a < len(cubes); a++ {
+			for b := a; b < len(cubes); b++ {
+				cover := logic.Cover{Variables: 2, Form: form}
+				for _, i := range []int{a, b} {
+					if i >= 0 {
+						cover.Cubes = append(cover.Cubes, cubes[i])
+					}
+				}
+				formula := FunctionCNF(cover)
+				for point := 0; point < 4; point++ {
+					if satisfiableAt(formula, 2, point) != (value(cover, point) == 1) {
+						t.Errorf("%s %s: formula at %d", form, cover.PrettyString(), point)
+					}
+				}
+				var buffer bytes.Buffer
+				if err := formula.WriteDIMACS(&buffer); err != nil {
+					t.Fatal(err)
+				}
+				parsed, err := ParseDIMACS(&buffer)
+				if err != nil {
+					t.Fatalf("%s %s: %v", form, cover.PrettyString(), err)
+				}
+				if clausesString(parsed) != clausesString(formula) || len(parsed.Comments) != len(formula.Comments) {
+					t.Errorf("%s %s: parsed\n%s", form, cover.PrettyString(), clausesString(parsed))
+				}
+			}
+		}
+	}
+}
+
+func TestWriteWCNF(t *testing.T) {
+	tests := []struct {
+		f    WCNF
+		want string
+	}{
+		{
+			WCNF{Hard: CNF{Variables: 0}},
+			"p wcnf 0 0 1\n",
+		},
+		{
+			func() WCNF {
+				f := WCNF{Hard: CNF{Variables: 2, Clauses: []Clause{{1, 2}}, Comments: []string{"cover"}}}
+				f.AddSoft(2, -1)
+				f.AddSoft(3, -2)
+				return f
+			}(),
+			"c cover\np wcnf 2 3 6\n6 1 2 0\n2 -1 0\n3 -2 0\n",
+		},
+	}
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		if err := test.f.WriteWCNF(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != test.want {
+			t.Errorf("%q, want %q", buffer.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+// Пакет sat - небольшой CDCL SAT-решатель для задач покрытия
+// и проверки эквивалентности ФАЛ
+//
+// Формулы задаются в КНФ с литералами в нотации DIMACS и читаются
+// и записываются в формате DIMACS CNF
+package sat
+
+import (
+	"github.com/AndreevSemen/asvt/logic"
+)
+
+// Литерал в нотации DIMACS: v - переменная v >= 1, -v - ее отрицание
+type Lit int
+
+// Функция возвращает номер переменной литерала
+func (l Lit) Var() int {
+	if l < 0 {
+		return int(-l)
+	}
+	return int(l)
+}
+
+// Функция возвращает отрицание литерала
+func (l Lit) Not() Lit {
+	return -l
+}
+
+// Дизъюнкт - дизъюнкция литералов
+type Clause []Lit
+
+// Формула в КНФ
+type CNF struct {
+	Variables int
+	Clauses   []Clause
+	// Комментарии, которые записываются в начало файла DIMACS
+	Comments []string
+}
+
+// Функция заводит новую переменную и возвращает ее прямой литерал
+func (f *CNF) NewVariable() Lit {
+	f.Variables++
+	return Lit(f.Variables)
+}
+
+// Функция добавляет дизъюнкт из литералов lits
+func (f *CNF) Add(lits ...Lit) {
+	f.Clauses = append(f.Clauses, append(Clause(nil), lits...))
+}
+
+// Результат решения
+type Status int
+
+const (
+	// Перебор остановлен до ответа
+	Unknown Status = iota
+	Satisfiable
+	Unsatisfiable
+)
+
+func (s Status) String() string {
+	switch s {
+	case Satisfiable:
+		return "SATISFIABLE"
+	case Unsatisfiable:
+		return "UNSATISFIABLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Модель: Model[v] - значение переменной v; Model[0] не используется
+type Model []bool
+
+// Функция возвращает значение литерала в модели
+func (m Model) Value(l Lit) bool {
+	return m[l.Var()] == (l > 0)
+}
+
+// Значения переменных в решателе
+const (
+	unassigned int8 = 0
+	valueTrue  int8 = 1
+	valueFalse int8 = -1
+)
+
+// Нет причины: переменная выбрана решением или не присвоена
+const noReason = -1
+
+// Первая перезагрузка происходит после стольких конфликтов,
+// следующие - через кратные им отрезки последовательности Луби
+const restartBase = 100
+
+// Состояние решателя
+// Внутри литерал переменной v (с нуля) кодируется числом 2v для прямого
+// литерала и 2v+1 для отрицания
+type solver struct {
+	tracker *logic.Tracker
+	clauses [][]int
+	// watches[l] - дизъюнкты, у которых l - один из двух наблюдаемых
+	// литералов (первый или второй)
+	watches  [][]int
+	assigns  []int8
+	level    []int
+	reason   []int
+	polarity []bool
+	activity []float64
+	varInc   float64
+	seen     []bool
+	trail    []int
+	trailLim []int
+	qhead    int
+	ok       bool
+}
+
+func newSolver(variables int, tracker *logic.Tracker) *solver {
+	s := &solver{
+		tracker:  tracker,
+		watches:  make([][]int, 2*variables),
+		assigns:  make([]int8, variables),
+		level:    make([]int, variables),
+		reason:   make([]int, variables),
+		polarity: make([]bool, variables),
+		activity: make([]float64, variables),
+		varInc:   1,
+		seen:     make([]bool, variables),
+		ok:       true,
+	}
+	for v := range s.reason {
+		s.reason[v] = noReason
+	}
+	return s
+}
+
+func internal(l Lit) int {
+	if l < 0 {
+		return 2*(int(-l)-1) + 1
+	}
+	return 2 * (int(l) - 1)
+}
+
+// Значение внутреннего литерала
+func (s *solver) value(l int) int8 {
+	v := s.assigns[l>>1]
+	if l&1 == 1 {
+		return -v
+	}
+	return v
+}
+
+func (s *solver) decisionLevel() int {
+	return len(s.trailLim)
+}
+
+func (s *solver) enqueue(l, reason int) {
+	v := l >> 1
+	if l&1 == 1 {
+		s.assigns[v] = valueFalse
+	} else {
+		s.assigns[v] = valueTrue
+	}
+	s.level[v] = s.decisionLevel()
+	s.reason[v] = reason
+	s.trail = append(s.trail, l)
+}
+
+// Функция добавляет исходный дизъюнкт на нулевом уровне
+// Повторные литералы убираются, тавтологии пропускаются
+func (s *solver) addClause(clause Clause) {
+	if !s.ok {
+		return
+	}
+	var lits []int
+	present := make(map[int]struct{}, len(clause))
+	for _, l := range clause {
+		internalLit := internal(l)
+		if _, found := present[internalLit^1]; found {
+			return
+		}
+		if _, found := present[internalLit]; found {
+			continue
+		}
+		present[internalLit] = struct{}{}
+		switch s.value(internalLit) {
+		case valueTrue:
+			return
+		case valueFalse:
+			continue
+		}
+		lits = append(lits, internalLit)
+	}
+	switch len(lits) {
+	case 0:
+		s.ok = false
+	case 1:
+		s.enqueue(lits[0], noReason)
+		s.ok = s.propagate() == noReason
+	default:
+		s.attach(lits)
+	}
+}
+
+func (s *solver) attach(lits []int) int {
+	index := len(s.clauses)
+	s.clauses = append(s.clauses, lits)
+	s.watches[lits[0]] = append(s.watches[lits[0]], index)
+	s.watches[lits[1]] = append(s.watches[lits[1]], index)
+	return index
+}
+
+// Функция распространяет присваивания по наблюдаемым литералам
+// Возвращает номер конфликтного дизъюнкта либо noReason
+func (s *solver) propagate() int {
+	for s.qhead < len(s.trail) {
+		falseLit := s.trail[s.qhead] ^ 1
+		s.qhead++
+		watching := s.watches[falseLit]
+		kept := watching[:0]
+		for i := 0; i < len(watching); i++ {
+			index := watching[i]
+			c := s.clauses[index]
+			if c[0] == falseLit {
+				c[0], c[1] = c[1], c[0]
+			}
+			if s.value(c[0]) == valueTrue {
+				kept = append(kept, index)
+				continue
+			}
+			// Ищем вместо ложного литерала другой, не ложный
+			moved := false
+			for k := 2; k < len(c); k++ {
+				if s.value(c[k]) != valueFalse {
+					c[1], c[k] = c[k], c[1]
+					s.watches[c[1]] = append(s.watches[c[1]], index)
+					moved = true
+					break
+				}
+			}
+			if moved {
+				continue
+			}
+			kept = append(kept, index)
+			if s.value(c[0]) == valueFalse {
+				kept = append(kept, watching[i+1:]...)
+				s.watches[falseLit] = kept
+				return index
+			}
+			s.enqueue(c[0], index)
+		}
+		s.watches[falseLit] = kept
+	}
+	return noReason
+}
+
+func (s *solver) bump(v int) {
+	s.activity[v] += s.varInc
+	if s.activity[v] > 1e100 {
+		for i := range s.activity {
+			s.activity[i] *= 1e-100
+		}
+		s.varInc *= 1e-100
+	}
+}
+
+// Функция строит дизъюнкт по первой точке сочленения (1UIP)
+// Возвращает выученный дизъюнкт, первый литерал которого - утверждаемый,
+// и уровень, на который нужно откатиться
+func (s *solver) analyze(conflict int) ([]int, int) {
+	learnt := []int{0}
+	pathCount := 0
+	p := -1
+	index := len(s.trail) - 1
+	for {
+		c := s.clauses[conflict]
+		start := 0
+		if p != -1 {
+			// Первый литерал дизъюнкта-причины - выведенный им литерал p
+			start = 1
+		}
+		for _, q := range c[start:] {
+			v := q >> 1
+			if s.seen[v] || s.level[v] == 0 {
+				continue
+			}
+			s.seen[v] = true
+			s.bump(v)
+			if s.level[v] >= s.decisionLevel() {
+				pathCount++
+			} else {
+				learnt = append(learnt, q)
+			}
+		}
+		for !s.seen[s.trail[index]>>1] {
+			index--
+		}
+		p = s.trail[index]
+		index--
+		conflict = s.reason[p>>1]
+		s.seen[p>>1] = false
+		pathCount--
+		if pathCount == 0 {
+			break
+		}
+	}
+	learnt[0] = p ^ 1
+
+	backtrack := 0
+	for i := 1; i < len(learnt); i++ {
+		s.seen[learnt[i]>>1] = false
+		if s.level[learnt[i]>>1] > backtrack {
+			backtrack = s.level[learnt[i]>>1]
+			// Литерал с наибольшим уровнем становится вторым наблюдаемым
+			learnt[1], learnt[i] = learnt[i], learnt[1]
+		}
+	}
+	s.varInc /= 0.95
+	return learnt, backtrack
+}
+
+func (s *solver) cancelUntil(level int) {
+	if s.decisionLevel() <= level {
+		return
+	}
+	for i := len(s.trail) - 1; i >= s.trailLim[level]; i-- {
+		v := s.trail[i] >> 1
+		s.polarity[v] = s.trail[i]&1 == 0
+		s.assigns[v] = unassigned
+		s.reason[v] = noReason
+	}
+	s.trail = s.trail[:s.trailLim[level]]
+	s.trailLim = s.trailLim[:level]
+	s.qhead = len(s.trail)
+}
+
+// Функция выбирает неприсвоенную переменную с наибольшей активностью
+// Возвращает -1, если все переменные присвоены
+func (s *solver) pickBranch() int {
+	best := -1
+	for v, assign := range s.assigns {
+		if assign == unassigned && (best == -1 || s.activity[v] > s.activity[best]) {
+			best = v
+		}
+	}
+	if best == -1 {
+		return -1
+	}
+	if s.polarity[best] {
+		return 2 * best
+	}
+	return 2*best + 1
+}
+
+// Функция возвращает i-й член последовательности Луби 1, 1, 2, 1, 1, 2, 4, ...
+func luby(i int) int {
+	size, power := 1, 1
+	for size < i+1 {
+		size = 2*size + 1
+		power *= 2
+	}
+	for size-1 != i {
+		size = (size - 1) / 2
+		power /= 2
+		if i >= size {
+			i -= size
+		}
+	}
+	return power
+}
+
+func (s *solver) search() Status {
+	if !s.ok {
+		return Unsatisfiable
+	}
+	restarts := 0
+	conflicts := 0
+	limit := restartBase * luby(restarts)
+	for {
+		conflict := s.propagate()
+		if conflict != noReason {
+			if s.decisionLevel() == 0 {
+				return Unsatisfiable
+			}
+			if !s.tracker.Step() {
+				return Unknown
+			}
+			conflicts++
+			learnt, backtrack := s.analyze(conflict)
+			s.cancelUntil(backtrack)
+			if len(learnt) == 1 {
+				s.enqueue(learnt[0], noReason)
+			} else {
+				s.enqueue(learnt[0], s.attach(learnt))
+			}
+			continue
+		}
+		if conflicts >= limit {
+			restarts++
+			conflicts = 0
+			limit = restartBase * luby(restarts)
+			s.cancelUntil(0)
+			continue
+		}
+		next := s.pickBranch()
+		if next == -1 {
+			return Satisfiable
+		}
+		s.trailLim = append(s.trailLim, len(s.trail))
+		s.enqueue(next, noReason)
+	}
+}
+
+// Функция решает формулу f
+// Конфликты учитываются как узлы перебора tracker; если он останавливает
+// перебор, возвращается Unknown
+// Для выполнимой формулы возвращается модель
+func Solve(f CNF, tracker *logic.Tracker) (Status, Model) {
+	tracker.SetStage("sat search")
+	s := newSolver(f.Variables, tracker)
+	for _, clause := range f.Clauses {
+		s.addClause(clause)
+	}
+	status := s.search()
+	if status != Satisfiable {
+		return status, nil
+	}
+	model := make(Model, f.Variables+1)
+	for v, assign := range s.assigns {
+		model[v+1] = assign == valueTrue
+	}
+	return status, model
+}
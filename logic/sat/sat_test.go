@@ -0,0 +1,125 @@
+package sat
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"math/rand"
+	"testing"
+)
+
+// Функция проверяет, что модель выполняет все дизъюнкты формулы
+func satisfies(f CNF, model Model) bool {
+	for _, clause := range f.Clauses {
+		var satisfied = false
+		for _, l := range clause {
+			satisfied = satisfied || model.Value(l)
+		}
+		if !satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция перебором всех моделей проверяет, выполнима ли формула
+func bruteForce(f CNF) bool {
+	model := make(Model, f.Variables+1)
+	for assignment := 0; assignment < 1<<uint(f.Variables); assignment++ {
+		for v := 1; v <= f.Variables; v++ {
+			model[v] = assignment&(1<<uint(v-1)) != 0
+		}
+		if satisfies(f, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// Функция строит формулу "pigeons голубей сидят в holes клетках по одному":
+// при pigeons > holes она невыполнима
+func pigeonhole(pigeons, holes int) CNF {
+	var f CNF
+	sits := make([][]Lit, pigeons)
+	for p := range sits {
+		sits[p] = make([]Lit, holes)
+		for h := range sits[p] {
+			sits[p][h] = f.NewVariable()
+		}
+		f.Add(sits[p]...)
+	}
+	for h := 0; h < holes; h++ {
+		for a := 0; a < pigeons; a++ {
+			for b := a + 1; b < pigeons; b++ {
+				f.Add(sits[a][h].Not(), sits[b][h].Not())
+			}
+		}
+	}
+	return f
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      CNF
+		status Status
+	}{
+		{"empty formula", CNF{Variables: 2}, Satisfiable},
+		{"empty clause", CNF{Variables: 1, Clauses: []Clause{{1}, {}}}, Unsatisfiable},
+		{"contradiction", CNF{Variables: 1, Clauses: []Clause{{1}, {-1}}}, Unsatisfiable},
+		{"tautology", CNF{Variables: 1, Clauses: []Clause{{1, -1}}}, Satisfiable},
+		{"implications", CNF{Variables: 3, Clauses: []Clause{{1}, {-1, 2}, {-2, 3}}}, Satisfiable},
+		{"all pairs", CNF{Variables: 2, Clauses: []Clause{{1, 2}, {-1, 2}, {1, -2}, {-1, -2}}}, Unsatisfiable},
+		{"three pairs", CNF{Variables: 2, Clauses: []Clause{{1, 2}, {-1, 2}, {1, -2}}}, Satisfiable},
+		{"pigeonhole 3 in 3", pigeonhole(3, 3), Satisfiable},
+		{"pigeonhole 4 in 3", pigeonhole(4, 3), Unsatisfiable},
+		{"pigeonhole 6 in 5", pigeonhole(6, 5), Unsatisfiable},
+	}
+	for _, test := range tests {
+		status, model := Solve(test.f, nil)
+		if status != test.status {
+			t.Errorf("%s: %s, want %s", test.name, status, test.status)
+			continue
+		}
+		if status == Satisfiable && (len(model) != test.f.Variables+1 || !satisfies(test.f, model)) {
+			t.Errorf("%s: model %v does not satisfy the formula", test.name, model)
+		}
+	}
+}
+
+// Случайные 3-КНФ около порога выполнимости решаются так же, как перебором
+func TestSolveRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	counts := make(map[Status]int)
+	for i := 0; i < 300; i++ {
+		f := CNF{Variables: 3 + random.Intn(8)}
+		for c := 0; c < f.Variables*43/10; c++ {
+			clause := make(Clause, 3)
+			for j := range clause {
+				clause[j] = Lit(1 + random.Intn(f.Variables))
+				if random.Intn(2) == 0 {
+					clause[j] = clause[j].Not()
+				}
+			}
+			f.Add(clause...)
+		}
+		status, model := Solve(f, nil)
+		counts[status]++
+		if want := bruteForce(f); (status == Satisfiable) != want || status == Unknown {
+			t.Fatalf("%v: %s, satisfiable %t", f.Clauses, status, want)
+		}
+		if status == Satisfiable && !satisfies(f, model) {
+			t.Fatalf("%v: model %v does not satisfy the formula", f.Clauses, model)
+		}
+	}
+	if counts[Satisfiable] == 0 || counts[Unsatisfiable] == 0 {
+		t.Errorf("degenerate random formulas: %v", counts)
+	}
+}
+
+func TestSolveBudget(t *testing.T) {
+	tracker := logic.NewTracker(context.Background(), logic.Options{Budget: logic.Budget{Nodes: 1}})
+	status, model := Solve(pigeonhole(7, 6), tracker)
+	if status != Unknown || model != nil || tracker.Err() != logic.ErrBudgetExhausted {
+		t.Errorf("%s, %v after the budget is exhausted", status, tracker.Err())
+	}
+}
@@ -0,0 +1,220 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Ошибка остановки перебора по исчерпанию бюджета
+var ErrBudgetExhausted = errors.New("search budget exhausted")
+
+// Бюджет перебора; нулевое значение ограничения означает, что оно не задано
+type Budget struct {
+	// Наибольшее время перебора
+	Time time.Duration
+	// Наибольшее число оцененных узлов перебора (комбинаций строк,
+	// ветвей поиска)
+	Nodes int64
+}
+
+// Сведения о ходе перебора для периодических отчетов
+type Progress struct {
+	// Этап, на котором идет перебор
+	Stage string
+	// Количество оцененных узлов перебора
+	Evaluated int64
+	// Стоимость лучшего найденного покрытия; -1, пока покрытие не найдено
+	BestCost int
+	Elapsed  time.Duration
+}
+
+// Интервал отчетов о ходе перебора по умолчанию
+const DefaultProgressInterval = time.Second
+
+// Через сколько узлов проверяются отмена, время и необходимость отчета
+const trackerCheckEvery = 256
+
+// Контроль перебора: отмена через контекст, бюджет и отчеты о ходе
+// Методы можно вызывать у nil, тогда перебор ничем не ограничен,
+// и из нескольких потоков параллельного перебора одновременно
+type Tracker struct {
+	ctx      context.Context
+	options  Options
+	start    time.Time
+	deadline time.Time
+	nodes    int64
+	bestCost int64
+	stopped  int32
+	// mutex защищает этап, время следующего отчета и причину остановки
+	mutex  sync.Mutex
+	stage  string
+	report time.Time
+	err    error
+}
+
+// Функция создает контроль перебора по контексту и параметрам минимизации
+func NewTracker(ctx context.Context, options Options) *Tracker {
+	t := &Tracker{
+		ctx:      ctx,
+		options:  options,
+		start:    time.Now(),
+		bestCost: -1,
+	}
+	if options.Budget.Time != 0 {
+		t.deadline = t.start.Add(options.Budget.Time)
+	}
+	t.report = t.start.Add(t.interval())
+	return t
+}
+
+func (t *Tracker) interval() time.Duration {
+	if t.options.ProgressInterval != 0 {
+		return t.options.ProgressInterval
+	}
+	return DefaultProgressInterval
+}
+
+// Функция возвращает число потоков перебора из параметров минимизации
+func (t *Tracker) Workers() int {
+	if t == nil || t.options.Workers < 1 {
+		return 1
+	}
+	return t.options.Workers
+}
+
+// Функция задает название текущего этапа перебора
+func (t *Tracker) SetStage(stage string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	t.stage = stage
+	t.mutex.Unlock()
+}
+
+func (t *Tracker) stop(err error) {
+	t.mutex.Lock()
+	if t.err == nil {
+		t.err = err
+	}
+	t.mutex.Unlock()
+	atomic.StoreInt32(&t.stopped, 1)
+}
+
+// Функция учитывает очередной узел перебора
+// Возвращает false, если перебор нужно остановить и вернуть лучшее
+// найденное покрытие; после остановки всегда возвращает false
+func (t *Tracker) Step() bool {
+	if t == nil {
+		return true
+	}
+	if atomic.LoadInt32(&t.stopped) != 0 {
+		return false
+	}
+	nodes := atomic.AddInt64(&t.nodes, 1)
+	if t.options.Budget.Nodes != 0 && nodes > t.options.Budget.Nodes {
+		t.stop(ErrBudgetExhausted)
+		return false
+	}
+	// Первый узел тоже проверяется, чтобы заранее отмененный контекст
+	// останавливал и короткий перебор
+	if nodes%trackerCheckEvery != 1 {
+		return true
+	}
+	select {
+	case <-t.ctx.Done():
+		t.stop(t.ctx.Err())
+		return false
+	default:
+	}
+	now := time.Now()
+	if !t.deadline.IsZero() && now.After(t.deadline) {
+		t.stop(ErrBudgetExhausted)
+		return false
+	}
+	if t.options.Progress != nil {
+		t.mutex.Lock()
+		report := now.After(t.report)
+		if report {
+			t.report = now.Add(t.interval())
+		}
+		t.mutex.Unlock()
+		if report {
+			t.options.Progress(t.Progress())
+		}
+	}
+	return true
+}
+
+// Функция запоминает стоимость найденного покрытия, если она лучше прежней
+func (t *Tracker) Found(cost int) {
+	if t == nil {
+		return
+	}
+	for {
+		best := atomic.LoadInt64(&t.bestCost)
+		if best != -1 && best <= int64(cost) {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&t.bestCost, best, int64(cost)) {
+			return
+		}
+	}
+}
+
+// Функция возвращает сведения о ходе перебора
+func (t *Tracker) Progress() Progress {
+	if t == nil {
+		return Progress{BestCost: -1}
+	}
+	t.mutex.Lock()
+	stage := t.stage
+	t.mutex.Unlock()
+	return Progress{
+		Stage:     stage,
+		Evaluated: atomic.LoadInt64(&t.nodes),
+		BestCost:  int(atomic.LoadInt64(&t.bestCost)),
+		Elapsed:   time.Since(t.start),
+	}
+}
+
+// Функция возвращает количество оцененных узлов перебора
+func (t *Tracker) Evaluated() int64 {
+	if t == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&t.nodes)
+}
+
+// Функция возвращает ошибку отмены контекста, которую алгоритм минимизации
+// должен вернуть вместе с лучшим найденным покрытием; исчерпание бюджета
+// ошибкой не считается
+func CanceledError(err error) error {
+	if err == ErrBudgetExhausted {
+		return nil
+	}
+	return err
+}
+
+// Функция возвращает причину остановки перебора: ошибку контекста либо
+// ErrBudgetExhausted; nil, если перебор не останавливался
+func (t *Tracker) Err() error {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.err
+}
+
+func (p Progress) String() string {
+	best := "none"
+	if p.BestCost != -1 {
+		best = fmt.Sprint(p.BestCost)
+	}
+	return fmt.Sprintf("%s: %d evaluated, best cost %s, %v", p.Stage, p.Evaluated, best, p.Elapsed.Round(time.Millisecond))
+}
@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrackerNodes(t *testing.T) {
+	tracker := NewTracker(context.Background(), Options{Budget: Budget{Nodes: 10}})
+	for i := 0; i < 10; i++ {
+		if !tracker.Step() {
+			t.Fatalf("stopped after %d nodes", i)
+		}
+	}
+	if tracker.Err() != nil {
+		t.Fatalf("error %v before the budget is exhausted", tracker.Err())
+	}
+	if tracker.Step() || tracker.Step() {
+		t.Error("Step after the budget is exhausted")
+	}
+	if tracker.Err() != ErrBudgetExhausted || CanceledError(tracker.Err()) != nil {
+		t.Errorf("error %v, want %v", tracker.Err(), ErrBudgetExhausted)
+	}
+}
+
+func TestTrackerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker := NewTracker(ctx, Options{})
+	cancel()
+	// Контекст проверяется уже на первом узле
+	if tracker.Step() {
+		t.Fatal("canceled search did not stop")
+	}
+	if !errors.Is(tracker.Err(), context.Canceled) || CanceledError(tracker.Err()) != context.Canceled {
+		t.Errorf("error %v, want %v", tracker.Err(), context.Canceled)
+	}
+}
+
+func TestTrackerTime(t *testing.T) {
+	tracker := NewTracker(context.Background(), Options{Budget: Budget{Time: time.Nanosecond}})
+	time.Sleep(time.Millisecond)
+	for i := 0; tracker.Step(); i++ {
+		if i > trackerCheckEvery {
+			t.Fatal("search did not stop after the deadline")
+		}
+	}
+	if tracker.Err() != ErrBudgetExhausted {
+		t.Errorf("error %v, want %v", tracker.Err(), ErrBudgetExhausted)
+	}
+}
+
+func TestTrackerProgress(t *testing.T) {
+	var reports []Progress
+	tracker := NewTracker(context.Background(), Options{
+		Progress:         func(p Progress) { reports = append(reports, p) },
+		ProgressInterval: time.Nanosecond,
+	})
+	tracker.SetStage("test")
+	tracker.Found(7)
+	tracker.Found(9)
+	tracker.Found(5)
+	time.Sleep(time.Millisecond)
+	for i := 0; i < trackerCheckEvery; i++ {
+		tracker.Step()
+	}
+	if len(reports) != 1 {
+		t.Fatalf("%d reports, want 1", len(reports))
+	}
+	if p := reports[0]; p.Stage != "test" || p.Evaluated != 1 || p.BestCost != 5 {
+		t.Errorf("report %v", p)
+	}
+}
+
+// Методы nil ничего не ограничивают
+func TestTrackerNil(t *testing.T) {
+	var tracker *Tracker
+	tracker.SetStage("test")
+	tracker.Found(1)
+	if !tracker.Step() || tracker.Err() != nil || tracker.Evaluated() != 0 || tracker.Workers() != 1 {
+		t.Error("nil tracker limits the search")
+	}
+	if p := tracker.Progress(); p.BestCost != -1 || p.String() != ": 0 evaluated, best cost none, 0s" {
+		t.Errorf("nil tracker progress %q", p)
+	}
+}
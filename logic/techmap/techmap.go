@@ -0,0 +1,364 @@
+// Пакет techmap - отображение минимальных форм на схемы из одного типа
+// вентилей: ДНФ - на схему И-НЕ/И-НЕ, КНФ - на схему ИЛИ-НЕ/ИЛИ-НЕ
+//
+// Вентили с числом входов больше допустимого раскладываются в деревья,
+// инверторы - вентили того же типа с объединенными входами. Вентили
+// размещаются в микросхемах серии 74 с наименьшим подходящим числом входов
+package techmap
+
+import (
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Тип вентиля: И-НЕ либо ИЛИ-НЕ
+type Op string
+
+const (
+	NAND Op = "NAND"
+	NOR  Op = "NOR"
+)
+
+// Микросхема: Gates вентилей op с FanIn входами
+type Chip struct {
+	Part   string
+	Op     Op
+	FanIn  int
+	Gates  int
+	Remark string
+}
+
+// Доступные микросхемы по возрастанию числа входов
+var Chips = []Chip{
+	{"7400", NAND, 2, 4, "quad 2-input NAND"},
+	{"7410", NAND, 3, 3, "triple 3-input NAND"},
+	{"7420", NAND, 4, 2, "dual 4-input NAND"},
+	{"7430", NAND, 8, 1, "single 8-input NAND"},
+	{"7402", NOR, 2, 4, "quad 2-input NOR"},
+	{"7427", NOR, 3, 3, "triple 3-input NOR"},
+	{"7425", NOR, 4, 2, "dual 4-input NOR with strobe"},
+}
+
+// Функция возвращает числа входов вентилей op в микросхемах по возрастанию
+func FanIns(op Op) []int {
+	var fanIns []int
+	for _, chip := range Chips {
+		if chip.Op == op {
+			fanIns = append(fanIns, chip.FanIn)
+		}
+	}
+	return fanIns
+}
+
+// Функция выбирает микросхему с наименьшим числом входов не меньше inputs
+// и не больше fanIn
+func chipFor(op Op, inputs, fanIn int) (Chip, bool) {
+	for _, chip := range Chips {
+		if chip.Op == op && chip.FanIn >= inputs && chip.FanIn <= fanIn {
+			return chip, true
+		}
+	}
+	return Chip{}, false
+}
+
+// Вентиль схемы; вентиль с одним входом - инвертор
+type Gate struct {
+	Name   string
+	Op     Op
+	Inputs []string
+}
+
+func (g Gate) String() string {
+	return fmt.Sprintf("%s = %s(%s)", g.Name, g.Op, strings.Join(g.Inputs, ", "))
+}
+
+// Схема из вентилей одного типа с не более чем FanIn входами
+// Входы схемы - переменные x_i и константы 0 и 1; инвертор переменной x_i
+// называется !x_i
+type Netlist struct {
+	Op     Op
+	FanIn  int
+	Gates  []Gate
+	Output string
+}
+
+// Состояние построения схемы
+type builder struct {
+	netlist *Netlist
+	names   int
+	// Пары взаимно обратных сигналов, связанных инвертором
+	inverted map[string]string
+}
+
+// Функция добавляет вентиль и возвращает имя его выхода
+func (b *builder) gate(inputs []string) string {
+	b.names++
+	name := "g" + strconv.Itoa(b.names)
+	b.netlist.Gates = append(b.netlist.Gates, Gate{Name: name, Op: b.netlist.Op, Inputs: inputs})
+	if len(inputs) == 1 {
+		b.inverted[name], b.inverted[inputs[0]] = inputs[0], name
+	}
+	return name
+}
+
+// Функция возвращает сигнал, обратный s: переиспользует уже имеющийся
+// либо добавляет инвертор
+func (b *builder) invert(s string) string {
+	if t, found := b.inverted[s]; found {
+		return t
+	}
+	if strings.HasPrefix(s, "x") {
+		name := "!" + s
+		b.netlist.Gates = append(b.netlist.Gates, Gate{Name: name, Op: b.netlist.Op, Inputs: []string{s}})
+		b.inverted[name], b.inverted[s] = s, name
+		return name
+	}
+	return b.gate([]string{s})
+}
+
+// Функция возвращает выход вентиля op от любого числа входов: при
+// превышении числа входов группы входов объединяются вентилями op
+// с инверторами (И из И-НЕ, ИЛИ из ИЛИ-НЕ), и вентиль op берется от групп
+func (b *builder) wide(inputs []string) string {
+	fanIn := b.netlist.FanIn
+	if len(inputs) <= fanIn {
+		return b.gate(inputs)
+	}
+	var groups []string
+	for i := 0; i < len(inputs); i += fanIn {
+		end := i + fanIn
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		if end-i == 1 {
+			groups = append(groups, inputs[i])
+			continue
+		}
+		groups = append(groups, b.invert(b.wide(inputs[i:end])))
+	}
+	return b.wide(groups)
+}
+
+// Функция строит двухуровневую схему: первый уровень - вентили op от
+// литералов каждого куба (их инверсии), второй - вентиль op от выходов
+// первого уровня
+func build(op Op, cover logic.Cover, fanIn int) (Netlist, error) {
+	// Вентили с числом входов, для которого нет микросхемы, пришлось бы
+	// размещать в более широких микросхемах, чем разрешено
+	if _, found := chipFor(op, fanIn, fanIn); !found {
+		fanIns := make([]string, 0, len(Chips))
+		for _, f := range FanIns(op) {
+			fanIns = append(fanIns, strconv.Itoa(f))
+		}
+		return Netlist{}, fmt.Errorf("no %s chip with %d inputs, fan-in must be one of %s", op, fanIn, strings.Join(fanIns, ", "))
+	}
+	n := Netlist{Op: op, FanIn: fanIn}
+	b := &builder{netlist: &n, inverted: make(map[string]string)}
+	// Значение пустого покрытия и покрытия с пустым кубом
+	empty, absorbing := "0", "1"
+	if op == NOR {
+		empty, absorbing = "1", "0"
+	}
+	if len(cover.Cubes) == 0 {
+		n.Output = empty
+		return n, nil
+	}
+	var first []string
+	for _, cube := range cover.Cubes {
+		if cube.Literals() == 0 {
+			n.Output = absorbing
+			n.Gates = nil
+			return n, nil
+		}
+	}
+	// Покрытие из одного литерала - сам литерал, а вентиль нужен
+	// только для инверсии
+	if len(cover.Cubes) == 1 && cover.Cubes[0].Literals() == 1 {
+		cube := cover.Cubes[0]
+		n.Output = "x" + strconv.Itoa(bits.TrailingZeros32(cube.Mask))
+		if cube.Complemented(cover.Form) != 0 {
+			n.Output = b.invert(n.Output)
+		}
+		return n, nil
+	}
+	for _, cube := range cover.Cubes {
+		complemented := cube.Complemented(cover.Form)
+		// Вентиль первого уровня от одного литерала - инвертор, поэтому
+		// его выход - переменная либо ее инверсия
+		if cube.Literals() == 1 {
+			variable := "x" + strconv.Itoa(bits.TrailingZeros32(cube.Mask))
+			if complemented != 0 {
+				first = append(first, variable)
+			} else {
+				first = append(first, b.invert(variable))
+			}
+			continue
+		}
+		var literals []string
+		for i := 0; i < cover.Variables; i++ {
+			bit := uint32(1) << uint(i)
+			if cube.Mask&bit == 0 {
+				continue
+			}
+			literal := "x" + strconv.Itoa(i)
+			if complemented&bit != 0 {
+				literal = b.invert(literal)
+			}
+			literals = append(literals, literal)
+		}
+		first = append(first, b.wide(literals))
+	}
+	if len(first) == 1 {
+		n.Output = b.invert(first[0])
+	} else {
+		n.Output = b.wide(first)
+	}
+	return n, nil
+}
+
+// Функция отображает ДНФ на схему И-НЕ/И-НЕ:
+// x1x2 + x3x4 = NAND(NAND(x1, x2), NAND(x3, x4))
+func MapNAND(cover logic.Cover, fanIn int) (Netlist, error) {
+	if cover.Form != logic.DNF {
+		return Netlist{}, fmt.Errorf("NAND-NAND mapping needs a dnf cover, got %s", cover.Form)
+	}
+	return build(NAND, cover, fanIn)
+}
+
+// Функция отображает КНФ на схему ИЛИ-НЕ/ИЛИ-НЕ:
+// (x1 + x2)(x3 + x4) = NOR(NOR(x1, x2), NOR(x3, x4))
+func MapNOR(cover logic.Cover, fanIn int) (Netlist, error) {
+	if cover.Form != logic.CNF {
+		return Netlist{}, fmt.Errorf("NOR-NOR mapping needs a cnf cover, got %s", cover.Form)
+	}
+	return build(NOR, cover, fanIn)
+}
+
+// Функция возвращает значения всех сигналов схемы на наборе point
+// функции от variables переменных
+func (n Netlist) values(point, variables int) map[string]int {
+	values := map[string]int{"0": 0, "1": 1}
+	for i := 0; i < variables; i++ {
+		values["x"+strconv.Itoa(i)] = point >> uint(variables-1-i) & 1
+	}
+	for _, gate := range n.Gates {
+		value := 1
+		if n.Op == NOR {
+			value = 0
+		}
+		for _, input := range gate.Inputs {
+			if n.Op == NAND {
+				value &= values[input]
+			} else {
+				value |= values[input]
+			}
+		}
+		values[gate.Name] = 1 - value
+	}
+	return values
+}
+
+// Функция вычисляет выход схемы на наборе point функции от variables переменных
+func (n Netlist) Value(point, variables int) int {
+	return n.values(point, variables)[n.Output]
+}
+
+// Функция проверяет схему на всех наборах spec
+// На неопределенных наборах допустимо любое значение
+func (n Netlist) Verify(spec logic.Spec) ([]logic.Mismatch, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	var mismatches []logic.Mismatch
+	for point, expected := range spec.Values {
+		if expected == logic.DontCare {
+			continue
+		}
+		if actual := n.Value(point, spec.Variables); actual != expected {
+			mismatches = append(mismatches, logic.Mismatch{
+				Point:    point,
+				Size:     spec.Variables,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+// Функция возвращает число уровней схемы - наибольшее число вентилей
+// на пути от входа к выходу
+func (n Netlist) Levels() int {
+	depth := make(map[string]int)
+	for _, gate := range n.Gates {
+		d := 0
+		for _, input := range gate.Inputs {
+			if depth[input] > d {
+				d = depth[input]
+			}
+		}
+		depth[gate.Name] = d + 1
+	}
+	return depth[n.Output]
+}
+
+// Число микросхем одного типа в схеме
+type ChipCount struct {
+	Chip     Chip
+	Gates    int
+	Packages int
+}
+
+func (c ChipCount) String() string {
+	return fmt.Sprintf("%s (%s): %d gates, %d chips", c.Chip.Part, c.Chip.Remark, c.Gates, c.Packages)
+}
+
+// Функция размещает вентили в микросхемах: каждый вентиль - в микросхеме
+// с наименьшим подходящим числом входов, лишние входы объединяются
+// Если для вентиля нет микросхемы не шире FanIn, возвращается ошибка
+func (n Netlist) Chips() ([]ChipCount, error) {
+	counts := make(map[string]*ChipCount)
+	for _, gate := range n.Gates {
+		chip, found := chipFor(n.Op, len(gate.Inputs), n.FanIn)
+		if !found {
+			return nil, fmt.Errorf("no %s chip for gate %s with fan-in %d", n.Op, gate, n.FanIn)
+		}
+		if counts[chip.Part] == nil {
+			counts[chip.Part] = &ChipCount{Chip: chip}
+		}
+		counts[chip.Part].Gates++
+	}
+	var result []ChipCount
+	for _, count := range counts {
+		count.Packages = (count.Gates + count.Chip.Gates - 1) / count.Chip.Gates
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Chip.FanIn < result[j].Chip.FanIn })
+	return result, nil
+}
+
+// Функция возвращает общее число микросхем
+func (n Netlist) Packages() (int, error) {
+	chips, err := n.Chips()
+	if err != nil {
+		return 0, err
+	}
+	packages := 0
+	for _, count := range chips {
+		packages += count.Packages
+	}
+	return packages, nil
+}
+
+func (n Netlist) String() string {
+	var formatted string
+	for _, gate := range n.Gates {
+		formatted += gate.String() + "\n"
+	}
+	formatted += "f = " + n.Output + "\n"
+	return formatted
+}
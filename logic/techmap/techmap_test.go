@@ -0,0 +1,193 @@
+package techmap
+
+import (
+	"context"
+	"fmt"
+	"github.com/AndreevSemen/asvt/logic"
+	"github.com/AndreevSemen/asvt/logic/internal/logictest"
+	_ "github.com/AndreevSemen/asvt/logic/nk"
+	_ "github.com/AndreevSemen/asvt/logic/qmc"
+	"math/rand"
+	"testing"
+)
+
+// Функция проверяет схему: она совпадает с функцией, у вентилей не больше
+// fanIn входов, а каждый вентиль участвует в вычислении выхода
+func check(t *testing.T, f []int, netlist Netlist, fanIn int) {
+	t.Helper()
+	if mismatches, _ := netlist.Verify(logic.NewSpec(f)); len(mismatches) != 0 {
+		t.Fatalf("%s %v, fan-in %d:\n%s%v", netlist.Op, f, fanIn, netlist, mismatches)
+	}
+	used := map[string]bool{netlist.Output: true}
+	for i := len(netlist.Gates) - 1; i >= 0; i-- {
+		gate := netlist.Gates[i]
+		if len(gate.Inputs) > fanIn || netlist.Op != gate.Op {
+			t.Fatalf("%s %v, fan-in %d: gate %s", netlist.Op, f, fanIn, gate)
+		}
+		if !used[gate.Name] {
+			t.Errorf("%s %v, fan-in %d: gate %s is not used:\n%s", netlist.Op, f, fanIn, gate, netlist)
+		}
+		for _, input := range gate.Inputs {
+			used[input] = true
+		}
+	}
+	if _, err := netlist.Chips(); err != nil {
+		t.Fatalf("%s %v, fan-in %d: %v", netlist.Op, f, fanIn, err)
+	}
+}
+
+// Функция минимизирует f алгоритмом backend и проверяет схемы, на которые
+// mapCover отображает покрытие при всех допустимых числах входов
+func mapFunction(t *testing.T, f []int, backend string, op Op, mapCover func(logic.Cover, int) (Netlist, error)) {
+	t.Helper()
+	result, err := logic.Minimize(context.Background(), backend, logic.NewSpec(f), logic.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, chip := range Chips {
+		if chip.Op != op {
+			continue
+		}
+		netlist, err := mapCover(result.Cover, chip.FanIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, f, netlist, chip.FanIn)
+	}
+}
+
+func TestMapExhaustive(t *testing.T) {
+	for n := 1; n <= 3; n++ {
+		logictest.ForEachFunction(n, true, func(f []int) {
+			mapFunction(t, f, "qmc", NAND, MapNAND)
+			mapFunction(t, f, "nk-cnf", NOR, MapNOR)
+		})
+	}
+}
+
+// Широкие вентили ФАЛ от 5 переменных раскладываются в деревья
+func TestMapRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		f := make([]int, 32)
+		for point := range f {
+			f[point] = random.Intn(2)
+		}
+		mapFunction(t, f, "qmc", NAND, MapNAND)
+		mapFunction(t, f, "nk-cnf", NOR, MapNOR)
+	}
+}
+
+func TestMap(t *testing.T) {
+	x0x1 := logic.Cube{Mask: 3, Values: 3}
+	wide := []logic.Cube{{Mask: 31, Values: 31}, {Mask: 1}, {Mask: 2, Values: 2}}
+	tests := []struct {
+		cover    logic.Cover
+		fanIn    int
+		netlist  string
+		levels   int
+		packages int
+	}{
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{x0x1, {Mask: 3}}}, 2,
+			"g1 = NAND(x0, x1)\n!x0 = NAND(x0)\n!x1 = NAND(x1)\ng2 = NAND(!x0, !x1)\ng3 = NAND(g1, g2)\nf = g3\n", 3, 2},
+		// Куб из трех литералов при двух входах - дерево из И-НЕ и инвертора
+		{logic.Cover{Variables: 3, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 7, Values: 5}, {Mask: 4}}}, 2,
+			"!x1 = NAND(x1)\ng1 = NAND(x0, !x1)\ng2 = NAND(g1)\ng3 = NAND(g2, x2)\ng4 = NAND(g3, x2)\nf = g4\n", 5, 2},
+		{logic.Cover{Variables: 5, Form: logic.DNF, Cubes: wide}, 8,
+			"g1 = NAND(x0, x1, x2, x3, x4)\n!x1 = NAND(x1)\ng2 = NAND(g1, x0, !x1)\nf = g2\n", 2, 3},
+		// Одиночный литерал не требует вентилей, кроме инвертора
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 1}}}, 2, "!x0 = NAND(x0)\nf = !x0\n", 1, 1},
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 2, Values: 2}}}, 2, "f = x1\n", 0, 0},
+		{logic.Cover{Variables: 2, Form: logic.DNF}, 2, "f = 0\n", 0, 0},
+		{logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{x0x1, {}}}, 2, "f = 1\n", 0, 0},
+		{logic.Cover{Variables: 2, Form: logic.CNF, Cubes: []logic.Cube{{Mask: 3, Values: 1}, {Mask: 2, Values: 2}}}, 2,
+			"!x0 = NOR(x0)\ng1 = NOR(!x0, x1)\ng2 = NOR(g1, x1)\nf = g2\n", 3, 1},
+		{logic.Cover{Variables: 2, Form: logic.CNF}, 2, "f = 1\n", 0, 0},
+		{logic.Cover{Variables: 2, Form: logic.CNF, Cubes: []logic.Cube{{}}}, 2, "f = 0\n", 0, 0},
+	}
+	for _, test := range tests {
+		mapCover := MapNAND
+		if test.cover.Form == logic.CNF {
+			mapCover = MapNOR
+		}
+		netlist, err := mapCover(test.cover, test.fanIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packages, err := netlist.Packages()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if netlist.String() != test.netlist || netlist.Levels() != test.levels || packages != test.packages {
+			t.Errorf("%s, fan-in %d: %d levels, %d chips\n%s", test.cover.PrettyString(), test.fanIn, netlist.Levels(), packages, netlist)
+		}
+	}
+}
+
+func TestMapErrors(t *testing.T) {
+	dnf := logic.Cover{Variables: 2, Form: logic.DNF, Cubes: []logic.Cube{{Mask: 3, Values: 3}}}
+	cnf := logic.Cover{Variables: 2, Form: logic.CNF, Cubes: []logic.Cube{{Mask: 3}}}
+	if _, err := MapNAND(cnf, 2); err == nil {
+		t.Error("NAND mapping accepted a cnf cover")
+	}
+	if _, err := MapNOR(dnf, 2); err == nil {
+		t.Error("NOR mapping accepted a dnf cover")
+	}
+	// Микросхем с 5-7 входами нет, а ИЛИ-НЕ на 8 входов нет в наборе
+	for _, fanIn := range []int{0, 1, 5, 6, 7, 9} {
+		if _, err := MapNAND(dnf, fanIn); err == nil {
+			t.Errorf("NAND mapping accepted fan-in %d", fanIn)
+		}
+	}
+	for _, fanIn := range []int{1, 5, 8} {
+		if _, err := MapNOR(cnf, fanIn); err == nil {
+			t.Errorf("NOR mapping accepted fan-in %d", fanIn)
+		}
+	}
+	if _, err := MapNAND(dnf, 5); err == nil || err.Error() != "no NAND chip with 5 inputs, fan-in must be one of 2, 3, 4, 8" {
+		t.Errorf("NAND fan-in 5: %v", err)
+	}
+	if fmt.Sprint(FanIns(NAND)) != "[2 3 4 8]" || fmt.Sprint(FanIns(NOR)) != "[2 3 4]" {
+		t.Errorf("fan-ins %v for NAND, %v for NOR", FanIns(NAND), FanIns(NOR))
+	}
+}
+
+func TestChips(t *testing.T) {
+	netlist := Netlist{Op: NAND, FanIn: 8}
+	for _, inputs := range []int{1, 1, 2, 2, 2, 3, 4, 4, 4, 5} {
+		netlist.Gates = append(netlist.Gates, Gate{Op: NAND, Inputs: make([]string, inputs)})
+	}
+	want := []string{
+		"7400 (quad 2-input NAND): 5 gates, 2 chips",
+		"7410 (triple 3-input NAND): 1 gates, 1 chips",
+		"7420 (dual 4-input NAND): 3 gates, 2 chips",
+		"7430 (single 8-input NAND): 1 gates, 1 chips",
+	}
+	chips, err := netlist.Chips()
+	if err != nil {
+		t.Fatal(err)
+	}
+	packages, err := netlist.Packages()
+	if err != nil || len(chips) != len(want) || packages != 6 {
+		t.Fatalf("chips %v, %d packages, %v", chips, packages, err)
+	}
+	for i, count := range chips {
+		if count.String() != want[i] {
+			t.Errorf("chips %v, want %v", chips, want)
+			break
+		}
+	}
+
+	// Вентилю шире FanIn и вентилю с 5 входами при FanIn 5 микросхемы нет
+	for _, wide := range []Netlist{
+		{Op: NOR, FanIn: 4, Gates: []Gate{{Name: "g1", Op: NOR, Inputs: make([]string, 5)}}},
+		{Op: NAND, FanIn: 5, Gates: []Gate{{Name: "g1", Op: NAND, Inputs: make([]string, 5)}}},
+	} {
+		if _, err := wide.Chips(); err == nil {
+			t.Errorf("%s gate with 5 inputs placed with fan-in %d", wide.Op, wide.FanIn)
+		}
+		if _, err := wide.Packages(); err == nil {
+			t.Errorf("%s gate with 5 inputs counted with fan-in %d", wide.Op, wide.FanIn)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Значение ФАЛ на наборе, где она не определена
+const DontCare = -1
+
+// Импликанта (или дизъюнкт), которую умеют проверять VerifyDNF и VerifyCNF
+type Implicant interface {
+	// Функция сообщает, входит ли в импликанту набор с номером point
+	// функции от n переменных; x0 соответствует старшему разряду номера
+	// Для дизъюнкта это означает, что дизъюнкт обращается на наборе в ноль
+	Contains(point, n int) bool
+	PrettyString() string
+}
+
+// Расхождение покрытия со спецификацией на одном наборе
+type Mismatch struct {
+	Point    int
+	Size     int
+	Expected int
+	Actual   int
+	// Импликанты, из-за которых покрытие приняло значение Actual
+	// Пусто, если значение получилось из-за того, что ни одна импликанта
+	// не вошла в набор
+	Implicants []Implicant
+}
+
+// Функция сообщает, что покрытие ошибочно принимает значение 1
+func (m Mismatch) IsFalsePositive() bool {
+	return m.Actual == 1
+}
+
+func (m Mismatch) String() string {
+	kind := "false negative"
+	if m.IsFalsePositive() {
+		kind = "false positive"
+	}
+	formatted := fmt.Sprintf("%s at %d (%0*b)", kind, m.Point, m.Size, m.Point)
+	if len(m.Implicants) != 0 {
+		var implicants []string
+		for _, implicant := range m.Implicants {
+			implicants = append(implicants, implicant.PrettyString())
+		}
+		formatted += ": by " + strings.Join(implicants, ", ")
+	}
+	return formatted
+}
+
+// Функция проверяет ДНФ на всех 2^n наборах
+// Ложное срабатывание - импликанта покрывает нулевой набор,
+// пропуск - единичный набор не покрыт ни одной импликантой
+// На неопределенных наборах (DontCare) допустимо любое значение
+func VerifyDNF(f []int, cover []Implicant) ([]Mismatch, error) {
+	return verify(f, cover, 1)
+}
+
+// Функция проверяет КНФ на всех 2^n наборах
+// Пропуск - дизъюнкт обращается в ноль на единичном наборе,
+// ложное срабатывание - на нулевом наборе не обнулился ни один дизъюнкт
+// На неопределенных наборах (DontCare) допустимо любое значение
+func VerifyCNF(f []int, clauses []Implicant) ([]Mismatch, error) {
+	return verify(f, clauses, 0)
+}
+
+// Функция проверяет сумму по модулю 2 конъюнкций на всех 2^n наборах
+// Значение на наборе - четность числа кубов, в которые он входит;
+// в расхождении перечисляются все такие кубы
+// На неопределенных наборах (DontCare) допустимо любое значение
+func VerifyESOP(f []int, cubes []Implicant) ([]Mismatch, error) {
+	variableNumber, err := Arity(f)
+	if err != nil {
+		return nil, err
+	}
+	var mismatches []Mismatch
+	for point, expected := range f {
+		if expected == DontCare {
+			continue
+		}
+		var hits []Implicant
+		for _, cube := range cubes {
+			if cube.Contains(point, variableNumber) {
+				hits = append(hits, cube)
+			}
+		}
+		if actual := len(hits) % 2; actual != expected {
+			mismatches = append(mismatches, Mismatch{
+				Point:      point,
+				Size:       variableNumber,
+				Expected:   expected,
+				Actual:     actual,
+				Implicants: hits,
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+// Функция вычисляет форму на всех наборах и сравнивает ее со спецификацией
+// hit - значение формы на наборе, в который вошла хотя бы одна импликанта
+// Некорректный вектор f - ошибка LengthError или ValueError
+func verify(f []int, cover []Implicant, hit int) ([]Mismatch, error) {
+	variableNumber, err := Arity(f)
+	if err != nil {
+		return nil, err
+	}
+	var mismatches []Mismatch
+	for point, expected := range f {
+		if expected == DontCare {
+			continue
+		}
+		var hits []Implicant
+		for _, implicant := range cover {
+			if implicant.Contains(point, variableNumber) {
+				hits = append(hits, implicant)
+			}
+		}
+		actual := 1 - hit
+		if len(hits) != 0 {
+			actual = hit
+		}
+		if actual != expected {
+			mismatches = append(mismatches, Mismatch{
+				Point:      point,
+				Size:       variableNumber,
+				Expected:   expected,
+				Actual:     actual,
+				Implicants: hits,
+			})
+		}
+	}
+	return mismatches, nil
+}
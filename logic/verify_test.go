@@ -0,0 +1,59 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	x0 := Cube{Mask: 1, Values: 1}
+	x1 := Cube{Mask: 2, Values: 2}
+	tests := []struct {
+		f          []int
+		form       Form
+		cubes      []Cube
+		mismatches string
+	}{
+		{[]int{0, 1, 1, 1}, DNF, []Cube{x0, x1}, ""},
+		{[]int{0, 1, 1, 1}, DNF, []Cube{x0}, "false negative at 1 (01)"},
+		{[]int{0, 1, 1, 0}, DNF, []Cube{x0, x1}, "false positive at 3 (11): by x0, x1"},
+		{[]int{0, 1, 1, DontCare}, DNF, []Cube{x0, x1}, ""},
+		{[]int{DontCare, DontCare, DontCare, DontCare}, DNF, nil, ""},
+		{[]int{0, 0, 0, 1}, DNF, []Cube{{}}, "false positive at 0 (00): by 1; false positive at 1 (01): by 1; false positive at 2 (10): by 1"},
+		// Дизъюнкт (x1 + x0) обращается в ноль только на наборе 00
+		{[]int{0, 1, 1, 1}, CNF, []Cube{{Mask: 3}}, ""},
+		{[]int{0, 1, 1, 1}, CNF, []Cube{{Mask: 1}}, "false negative at 1 (01): by x0"},
+		{[]int{0, 1, 1, 1}, CNF, nil, "false positive at 0 (00)"},
+		{[]int{0, 1, 1, 0}, ESOP, []Cube{x0, x1}, ""},
+		{[]int{0, 1, 1, 1}, ESOP, []Cube{x0, x1}, "false negative at 3 (11): by x0, x1"},
+		{[]int{1, 0, 0, 1}, ESOP, []Cube{{}, x0, x1}, ""},
+		{[]int{1, 0, DontCare, 1}, ESOP, []Cube{x0}, "false negative at 0 (00)"},
+	}
+	for _, test := range tests {
+		cover := Cover{Variables: 2, Form: test.form, Cubes: test.cubes}
+		mismatches, err := cover.Verify(test.f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var formatted []string
+		for _, mismatch := range mismatches {
+			formatted = append(formatted, mismatch.String())
+		}
+		if got := strings.Join(formatted, "; "); got != test.mismatches {
+			t.Errorf("%v %s %s: %q, want %q", test.f, test.form, cover.PrettyString(), got, test.mismatches)
+		}
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	if _, err := VerifyDNF([]int{0, 1, 1}, nil); err == nil {
+		t.Error("VerifyDNF accepted a vector of length 3")
+	}
+	if _, err := VerifyESOP([]int{0, 3}, nil); err == nil {
+		t.Error("VerifyESOP accepted value 3")
+	}
+	cover := Cover{Variables: 3, Form: CNF}
+	if _, err := cover.Verify([]int{0, 1, 1, 1}); err == nil {
+		t.Error("Verify accepted a cover of 3 variables for a function of 2")
+	}
+}
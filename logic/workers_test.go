@@ -0,0 +1,35 @@
+package logic_test
+
+import (
+	"context"
+	"github.com/AndreevSemen/asvt/logic"
+	"math/rand"
+	"testing"
+)
+
+// Результат не зависит от числа потоков перебора
+func TestWorkersDeterministic(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 8; i++ {
+		f := make([]int, 32)
+		for point := range f {
+			f[point] = []int{0, 1, 1, logic.DontCare}[random.Intn(4)]
+		}
+		spec := logic.NewSpec(f)
+		for _, name := range []string{"qmc", "nk", "nk-cnf"} {
+			var covers []string
+			var costs []int
+			for _, workers := range []int{1, 4} {
+				result, err := logic.Minimize(context.Background(), name, spec, logic.Options{Workers: workers})
+				if err != nil {
+					t.Fatal(err)
+				}
+				covers = append(covers, result.Cover.PrettyString())
+				costs = append(costs, result.Cost)
+			}
+			if covers[0] != covers[1] || costs[0] != costs[1] {
+				t.Errorf("%s %v: %s (%d) on 1 worker, %s (%d) on 4", name, f, covers[0], costs[0], covers[1], costs[1])
+			}
+		}
+	}
+}